@@ -0,0 +1,516 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chasankm/sudoku-solver/pkg/generator"
+	"github.com/chasankm/sudoku-solver/pkg/solver"
+)
+
+func runSolve(e *env, args []string) int {
+	o := &options{}
+	fs := newFlagSet(e, "solve")
+	o.inputFlags(fs)
+	o.outputFlag(fs, "text", strings.Join(solver.ResponseFormats, ", "))
+	o.workerFlags(fs)
+	o.solveFlags(fs)
+	o.backendFlag(fs)
+	fs.BoolVar(&o.onlyUnsolved, "only-unsolved", false, "print only the boards which are not solved")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	writer, err := solver.NewResponseWriter(e.stdout, o.output)
+	if err != nil {
+		_, _ = fmt.Fprintf(e.stderr, "sudoku: %s\n", err.Error())
+		return exitUsage
+	}
+	backend, err := solver.ParseBackend(o.backend)
+	if err != nil {
+		_, _ = fmt.Fprintf(e.stderr, "sudoku: %s\n", err.Error())
+		return exitUsage
+	}
+	solverOptions := []solver.Option{solver.WithBackend(backend)}
+	if o.noBacktrack {
+		solverOptions = append(solverOptions, solver.WithoutBacktracking())
+	}
+	s, err := solver.New(solverOptions...)
+	if err != nil {
+		_, _ = fmt.Fprintf(e.stderr, "sudoku: %s\n", err.Error())
+		return exitUsage
+	}
+	boards, failed, code, ok := loadBoards(e, o, fs.Args())
+	if !ok {
+		return code
+	}
+
+	valid := make([]*solver.Board, 0, len(boards))
+	for _, board := range boards {
+		if board != nil {
+			valid = append(valid, board)
+		}
+	}
+	results := solver.SolveAll(context.Background(), valid, solver.BatchOptions{
+		Solver:  s,
+		Workers: o.workers,
+		Timeout: o.timeout,
+		Ordered: true,
+	})
+	var writeErr error
+	for result := range results {
+		if !result.Response.IsSolved {
+			failed = true
+		} else if o.onlyUnsolved {
+			continue
+		}
+		if writeErr == nil {
+			writeErr = writer.Write(result.Response)
+		}
+	}
+	if writeErr != nil {
+		_, _ = fmt.Fprintf(e.stderr, "sudoku: %s\n", writeErr.Error())
+		return exitFailure
+	}
+	if err := writer.Flush(); err != nil {
+		_, _ = fmt.Fprintf(e.stderr, "sudoku: %s\n", err.Error())
+		return exitFailure
+	}
+	return exitCode(failed)
+}
+
+type rateRow struct {
+	Index           int     `json:"index"`
+	Givens          int     `json:"givens"`
+	Difficulty      string  `json:"difficulty"`
+	Score           float64 `json:"score"`
+	HardestStrategy string  `json:"hardest_strategy"`
+	BackTracking    bool    `json:"backtracking"`
+}
+
+func (r rateRow) values() []string {
+	return []string{
+		strconv.Itoa(r.Index), strconv.Itoa(r.Givens), r.Difficulty, strconv.FormatFloat(r.Score, 'f', 2, 64),
+		r.HardestStrategy, strconv.FormatBool(r.BackTracking),
+	}
+}
+
+func runRate(e *env, args []string) int {
+	o := &options{}
+	fs := newFlagSet(e, "rate")
+	o.inputFlags(fs)
+	o.outputFlag(fs, "text", tableFormats)
+	o.workerFlags(fs)
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if !validTableFormat(o.output) {
+		_, _ = fmt.Fprintf(e.stderr, "sudoku: unknown output format: %q\n", o.output)
+		return exitUsage
+	}
+	boards, failed, code, ok := loadBoards(e, o, fs.Args())
+	if !ok {
+		return code
+	}
+
+	rows := make([]row, len(boards))
+	errs := make([]error, len(boards))
+	forEach(len(boards), o.workers, func(index int) {
+		if boards[index] == nil {
+			return
+		}
+		rating, err := solver.Rate(boards[index])
+		if err != nil {
+			errs[index] = err
+			return
+		}
+		givens, _ := boards[index].GetGivensAndBackTrack()
+		rows[index] = rateRow{
+			Index:           index + 1,
+			Givens:          givens,
+			Difficulty:      solver.Levels[rating.Difficulty],
+			Score:           rating.Score,
+			HardestStrategy: rating.HardestStrategy.String(),
+			BackTracking:    rating.BackTrackingUsed,
+		}
+	})
+	return writeRows(e, o.output, []string{"index", "givens", "difficulty", "score", "hardest_strategy", "backtracking"}, rows, errs, failed)
+}
+
+// writeRows reports the errors on stderr and writes the rows which are not nil
+func writeRows(e *env, format string, columns []string, rows []row, errs []error, failed bool) int {
+	written := make([]row, 0, len(rows))
+	for index, r := range rows {
+		if errs[index] != nil {
+			_, _ = fmt.Fprintf(e.stderr, "sudoku: puzzle %d: %s\n", index+1, errs[index].Error())
+			failed = true
+		}
+		if r != nil {
+			written = append(written, r)
+		}
+	}
+	if err := writeTable(e.stdout, format, columns, written); err != nil {
+		_, _ = fmt.Fprintf(e.stderr, "sudoku: %s\n", err.Error())
+		return exitFailure
+	}
+	return exitCode(failed)
+}
+
+func runGenerate(e *env, args []string) int {
+	fs := newFlagSet(e, "generate")
+	difficulty := fs.String("difficulty", "easy", "difficulty: easy, medium, hard, expert or evil")
+	symmetry := fs.String("symmetry", "none", "symmetry of the givens: none, rotational, mirror or diagonal")
+	seed := fs.Uint64("seed", 0, "seed of the first puzzle, the next puzzles use the following seeds; 0 picks a random seed")
+	count := fs.Int("count", 1, "number of puzzles")
+	attempts := fs.Int("attempts", 0, "maximum attempts for each puzzle; 0 uses the generator default")
+	output := fs.String("output", "line", "output format: line, grid, sdk, sdm, ss or json")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	format, err := solver.ParseFormat(*output)
+	if err != nil || format == solver.AutoFormat {
+		_, _ = fmt.Fprintf(e.stderr, "sudoku: unknown output format: %q\n", *output)
+		return exitUsage
+	}
+	level, err := solver.ParseDifficulty(*difficulty)
+	if err != nil {
+		_, _ = fmt.Fprintf(e.stderr, "sudoku: %s\n", err.Error())
+		return exitUsage
+	}
+	kind, err := generator.ParseSymmetry(*symmetry)
+	if err != nil {
+		_, _ = fmt.Fprintf(e.stderr, "sudoku: %s\n", err.Error())
+		return exitUsage
+	}
+	first := *seed
+	if first == 0 {
+		first = uint64(time.Now().UnixNano())
+	}
+
+	puzzles := make([]*solver.Puzzle, 0, *count)
+	for i := 0; i < *count; i++ {
+		generated, err := generator.Generate(generator.Options{
+			Seed:        first + uint64(i),
+			Symmetry:    kind,
+			Difficulty:  level,
+			MaxAttempts: *attempts,
+		})
+		if err != nil {
+			_, _ = fmt.Fprintf(e.stderr, "sudoku: %s\n", err.Error())
+			return exitFailure
+		}
+		puzzles = append(puzzles, &solver.Puzzle{
+			Name:    fmt.Sprintf("%s #%d", solver.Levels[generated.Difficulty], generated.Seed),
+			Source:  "sudoku-solver generator",
+			Comment: fmt.Sprintf("difficulty %s, symmetry %s, seed %d", solver.Levels[generated.Difficulty], generated.Symmetry, generated.Seed),
+			Givens:  generated.Givens,
+		})
+	}
+	if err := solver.WritePuzzles(e.stdout, format, puzzles); err != nil {
+		_, _ = fmt.Fprintf(e.stderr, "sudoku: %s\n", err.Error())
+		return exitFailure
+	}
+	return exitOK
+}
+
+type validateRow struct {
+	Index     int    `json:"index"`
+	Valid     bool   `json:"valid"`
+	Unique    bool   `json:"unique"`
+	Solutions int    `json:"solutions"`
+	ErrorType string `json:"error_type,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+func (r validateRow) values() []string {
+	return []string{
+		strconv.Itoa(r.Index), strconv.FormatBool(r.Valid), strconv.FormatBool(r.Unique), strconv.Itoa(r.Solutions),
+		r.ErrorType, r.Error,
+	}
+}
+
+func runValidate(e *env, args []string) int {
+	o := &options{}
+	fs := newFlagSet(e, "validate")
+	o.inputFlags(fs)
+	o.outputFlag(fs, "text", tableFormats)
+	o.workerFlags(fs)
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if !validTableFormat(o.output) {
+		_, _ = fmt.Fprintf(e.stderr, "sudoku: unknown output format: %q\n", o.output)
+		return exitUsage
+	}
+	puzzles, err := readPuzzles(e, o.format, fs.Args())
+	if err != nil {
+		_, _ = fmt.Fprintf(e.stderr, "sudoku: %s\n", err.Error())
+		return exitInput
+	}
+
+	rows := make([]row, len(puzzles))
+	failed := false
+	forEach(len(puzzles), o.workers, func(index int) {
+		result := validateRow{Index: index + 1}
+		board, err := puzzles[index].Board()
+		if err == nil {
+			result.Valid = true
+			result.Solutions = solver.CountSolutions(board, 2)
+			switch result.Solutions {
+			case 0:
+				err = &solver.NoSolutionError{}
+			case 1:
+				result.Unique = true
+			default:
+				err = &solver.MultipleSolutionsError{Count: result.Solutions}
+			}
+		}
+		if info := solver.NewErrorInfo(err); info != nil {
+			result.ErrorType, result.Error = string(info.Type), info.Message
+		}
+		rows[index] = result
+	})
+	for _, r := range rows {
+		if !r.(validateRow).Unique {
+			failed = true
+		}
+	}
+	return writeRows(e, o.output, []string{"index", "valid", "unique", "solutions", "error_type", "error"}, rows, make([]error, len(rows)), failed)
+}
+
+type countRow struct {
+	Index        int  `json:"index"`
+	Solutions    int  `json:"solutions"`
+	LimitReached bool `json:"limit_reached"`
+}
+
+func (r countRow) values() []string {
+	return []string{strconv.Itoa(r.Index), strconv.Itoa(r.Solutions), strconv.FormatBool(r.LimitReached)}
+}
+
+func runCount(e *env, args []string) int {
+	o := &options{}
+	fs := newFlagSet(e, "count")
+	o.inputFlags(fs)
+	o.outputFlag(fs, "text", tableFormats)
+	o.workerFlags(fs)
+	o.backendFlag(fs)
+	limit := fs.Int("limit", 1000, "stop counting after this many solutions")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if !validTableFormat(o.output) {
+		_, _ = fmt.Fprintf(e.stderr, "sudoku: unknown output format: %q\n", o.output)
+		return exitUsage
+	}
+	backend, err := solver.ParseBackend(o.backend)
+	if err != nil {
+		_, _ = fmt.Fprintf(e.stderr, "sudoku: %s\n", err.Error())
+		return exitUsage
+	}
+	s, err := solver.New(solver.WithBackend(backend))
+	if err != nil {
+		_, _ = fmt.Fprintf(e.stderr, "sudoku: %s\n", err.Error())
+		return exitUsage
+	}
+	if *limit < 1 {
+		_, _ = fmt.Fprintln(e.stderr, "sudoku: limit should be at least 1")
+		return exitUsage
+	}
+	boards, failed, code, ok := loadBoards(e, o, fs.Args())
+	if !ok {
+		return code
+	}
+
+	rows := make([]row, len(boards))
+	forEach(len(boards), o.workers, func(index int) {
+		if boards[index] == nil {
+			return
+		}
+		solutions := s.CountSolutions(boards[index], *limit)
+		rows[index] = countRow{Index: index + 1, Solutions: solutions, LimitReached: solutions >= *limit}
+	})
+	return writeRows(e, o.output, []string{"index", "solutions", "limit_reached"}, rows, make([]error, len(rows)), failed)
+}
+
+type hintRow struct {
+	Index       int    `json:"index"`
+	Strategy    string `json:"strategy"`
+	Targets     string `json:"targets"`
+	Explanation string `json:"explanation"`
+}
+
+func (r hintRow) values() []string {
+	return []string{strconv.Itoa(r.Index), r.Strategy, r.Targets, r.Explanation}
+}
+
+func runHint(e *env, args []string) int {
+	o := &options{}
+	fs := newFlagSet(e, "hint")
+	o.inputFlags(fs)
+	o.outputFlag(fs, "text", tableFormats)
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if !validTableFormat(o.output) {
+		_, _ = fmt.Fprintf(e.stderr, "sudoku: unknown output format: %q\n", o.output)
+		return exitUsage
+	}
+	boards, failed, code, ok := loadBoards(e, o, fs.Args())
+	if !ok {
+		return code
+	}
+
+	rows := make([]row, len(boards))
+	errs := make([]error, len(boards))
+	for index, board := range boards {
+		if board == nil {
+			continue
+		}
+		hint, err := board.Hint()
+		if err != nil {
+			errs[index] = err
+			continue
+		}
+		targets := make([]string, 0, len(hint.Targets))
+		for _, target := range hint.Targets {
+			targets = append(targets, target.String())
+		}
+		rows[index] = hintRow{
+			Index:       index + 1,
+			Strategy:    hint.Strategy.String(),
+			Targets:     strings.Join(targets, " "),
+			Explanation: hint.Explanation,
+		}
+	}
+	return writeRows(e, o.output, []string{"index", "strategy", "targets", "explanation"}, rows, errs, failed)
+}
+
+func runConvert(e *env, args []string) int {
+	o := &options{}
+	fs := newFlagSet(e, "convert")
+	o.inputFlags(fs)
+	o.outputFlag(fs, "line", "line, grid, sdk, sdm, ss or json")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	format, err := solver.ParseFormat(o.output)
+	if err != nil || format == solver.AutoFormat {
+		_, _ = fmt.Fprintf(e.stderr, "sudoku: unknown output format: %q\n", o.output)
+		return exitUsage
+	}
+	puzzles, err := readPuzzles(e, o.format, fs.Args())
+	if err != nil {
+		_, _ = fmt.Fprintf(e.stderr, "sudoku: %s\n", err.Error())
+		return exitInput
+	}
+	if err := solver.WritePuzzles(e.stdout, format, puzzles); err != nil {
+		_, _ = fmt.Fprintf(e.stderr, "sudoku: %s\n", err.Error())
+		return exitFailure
+	}
+	return exitOK
+}
+
+type benchRow struct {
+	Boards          int     `json:"boards"`
+	Rounds          int     `json:"rounds"`
+	Workers         int     `json:"workers"`
+	Solved          int     `json:"solved"`
+	Unsolved        int     `json:"unsolved"`
+	BackTracked     int     `json:"backtracked"`
+	ElapsedNs       int64   `json:"elapsed_ns"`
+	MeanNs          int64   `json:"mean_ns"`
+	BoardsPerSecond float64 `json:"boards_per_second"`
+}
+
+func (r benchRow) values() []string {
+	return []string{
+		strconv.Itoa(r.Boards), strconv.Itoa(r.Rounds), strconv.Itoa(r.Workers), strconv.Itoa(r.Solved),
+		strconv.Itoa(r.Unsolved), strconv.Itoa(r.BackTracked), time.Duration(r.ElapsedNs).String(),
+		time.Duration(r.MeanNs).String(), strconv.FormatFloat(r.BoardsPerSecond, 'f', 1, 64),
+	}
+}
+
+func runBench(e *env, args []string) int {
+	o := &options{}
+	fs := newFlagSet(e, "bench")
+	o.inputFlags(fs)
+	o.outputFlag(fs, "text", tableFormats)
+	o.workerFlags(fs)
+	o.solveFlags(fs)
+	rounds := fs.Int("rounds", 1, "number of times every board is solved")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if !validTableFormat(o.output) {
+		_, _ = fmt.Fprintf(e.stderr, "sudoku: unknown output format: %q\n", o.output)
+		return exitUsage
+	}
+	if *rounds < 1 || o.workers < 1 {
+		_, _ = fmt.Fprintln(e.stderr, "sudoku: rounds and workers should be at least 1")
+		return exitUsage
+	}
+	solverOptions := make([]solver.Option, 0)
+	if o.noBacktrack {
+		solverOptions = append(solverOptions, solver.WithoutBacktracking())
+	}
+	s, err := solver.New(solverOptions...)
+	if err != nil {
+		_, _ = fmt.Fprintf(e.stderr, "sudoku: %s\n", err.Error())
+		return exitUsage
+	}
+	puzzles, err := readPuzzles(e, o.format, fs.Args())
+	if err != nil {
+		_, _ = fmt.Fprintf(e.stderr, "sudoku: %s\n", err.Error())
+		return exitInput
+	}
+	// The boards are solved in place, so every round solves new boards of the puzzles
+	rounded := make([][]*solver.Board, *rounds)
+	failed := false
+	for round := range rounded {
+		rounded[round], failed = newBoards(e, puzzles)
+		if failed {
+			return exitFailure
+		}
+	}
+
+	boards := make([]*solver.Board, 0, len(puzzles)**rounds)
+	for _, round := range rounded {
+		boards = append(boards, round...)
+	}
+	begin := time.Now()
+	responses := make([]*solver.SolveResponse, 0, len(boards))
+	for result := range solver.SolveAll(context.Background(), boards, solver.BatchOptions{Solver: s, Workers: o.workers, Timeout: o.timeout}) {
+		responses = append(responses, result.Response)
+	}
+	elapsed := time.Since(begin)
+
+	result := benchRow{
+		Boards:    len(puzzles),
+		Rounds:    *rounds,
+		Workers:   o.workers,
+		ElapsedNs: elapsed.Nanoseconds(),
+	}
+	for _, response := range responses {
+		if response.IsSolved {
+			result.Solved++
+		} else {
+			result.Unsolved++
+		}
+		if response.BackTrackingUsed {
+			result.BackTracked++
+		}
+	}
+	if len(responses) > 0 {
+		result.MeanNs = elapsed.Nanoseconds() / int64(len(responses))
+		result.BoardsPerSecond = float64(len(responses)) / elapsed.Seconds()
+	}
+	columns := []string{"boards", "rounds", "workers", "solved", "unsolved", "backtracked", "elapsed", "mean", "boards_per_second"}
+	if err := writeTable(e.stdout, o.output, columns, []row{result}); err != nil {
+		_, _ = fmt.Fprintf(e.stderr, "sudoku: %s\n", err.Error())
+		return exitFailure
+	}
+	return exitCode(result.Unsolved > 0)
+}
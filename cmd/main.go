@@ -1,130 +1,231 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
-	"log"
+	"io"
+	"os"
 	"runtime"
-	"slices"
 	"sync"
 	"time"
 
 	"github.com/chasankm/sudoku-solver/pkg/solver"
 )
 
-type Results struct {
-	Solutions map[int]*solver.SolveResponse
-	Mutex     sync.Mutex
+const (
+	exitOK = 0
+	// exitFailure is returned when a board is invalid, unsolved, not unique or has no hint
+	exitFailure = 1
+	// exitUsage is returned for an unknown command or invalid flags
+	exitUsage = 2
+	// exitInput is returned when the input can't be read or parsed
+	exitInput = 3
+)
+
+// env keeps the streams of the CLI so the commands can be run with other streams than the standard ones
+type env struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
 }
 
-func (r *Results) AddSolution(index int, solution *solver.SolveResponse) {
-	r.Mutex.Lock()
-	r.Solutions[index] = solution
-	r.Mutex.Unlock()
+type command struct {
+	name    string
+	summary string
+	run     func(e *env, args []string) int
 }
 
-func (r *Results) PrintAll() {
-	indexes := make([]int, 0, len(r.Solutions))
-	for index := range r.Solutions {
-		indexes = append(indexes, index)
-	}
-	slices.Sort(indexes)
-	for _, index := range indexes {
-		solution, ok := r.Solutions[index]
-		if ok {
-			fmt.Printf("Index: %d\n", index)
-			fmt.Printf("%s", solution.Print())
-		}
+func commandList() []command {
+	return []command{
+		{name: "solve", summary: "solve the puzzles and print the solve results", run: runSolve},
+		{name: "rate", summary: "rate the puzzles by the strategies they need", run: runRate},
+		{name: "generate", summary: "generate puzzles with a unique solution", run: runGenerate},
+		{name: "validate", summary: "check that the puzzles are valid and have a unique solution", run: runValidate},
+		{name: "count", summary: "count the solutions of the puzzles", run: runCount},
+		{name: "hint", summary: "print the easiest next deduction of the puzzles", run: runHint},
+		{name: "convert", summary: "convert the puzzles between the file formats", run: runConvert},
+		{name: "bench", summary: "measure the solve throughput", run: runBench},
 	}
 }
 
-func (r *Results) NumberOfUnsolved() int {
-	total := 0
-	for _, v := range r.Solutions {
-		if !v.IsSolved {
-			total += 1
-		}
-	}
-	return total
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
 }
 
-func (r *Results) PrintUnsolved() {
-	indexes := make([]int, 0, len(r.Solutions))
-	for index, solution := range r.Solutions {
-		if !solution.IsSolved {
-			indexes = append(indexes, index)
-		}
+func run(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	e := &env{stdin: stdin, stdout: stdout, stderr: stderr}
+	if len(args) == 0 {
+		usage(stderr)
+		return exitUsage
+	}
+	switch args[0] {
+	case "help", "-h", "-help", "--help":
+		usage(stdout)
+		return exitOK
 	}
-	slices.Sort(indexes)
-	for _, index := range indexes {
-		solution, ok := r.Solutions[index]
-		if ok {
-			fmt.Printf("Index: %d\n", index)
-			fmt.Printf("%s", solution.Print())
+	for _, c := range commandList() {
+		if c.name == args[0] {
+			return c.run(e, args[1:])
 		}
 	}
+	_, _ = fmt.Fprintf(stderr, "sudoku: unknown command %q\n\n", args[0])
+	usage(stderr)
+	return exitUsage
 }
 
-func SolveBoardsInBatch(results *Results, boards map[int]*solver.Board) int {
-	var wg sync.WaitGroup
+func usage(w io.Writer) {
+	_, _ = fmt.Fprintln(w, "Usage: sudoku <command> [flags] [files...]")
+	_, _ = fmt.Fprintln(w, "")
+	_, _ = fmt.Fprintln(w, "The puzzles are read from the given files or from stdin when no file or \"-\" is given.")
+	_, _ = fmt.Fprintln(w, "")
+	_, _ = fmt.Fprintln(w, "Commands:")
+	for _, c := range commandList() {
+		_, _ = fmt.Fprintf(w, "  %-9s %s\n", c.name, c.summary)
+	}
+	_, _ = fmt.Fprintln(w, "")
+	_, _ = fmt.Fprintln(w, "Run \"sudoku <command> -h\" for the flags of a command.")
+}
 
-	for idx, b := range boards {
-		wg.Add(1)
-		go func(index int, results *Results, board *solver.Board, w *sync.WaitGroup) {
-			defer w.Done()
-			solveResponse := board.Solve()
-			results.AddSolution(index, solveResponse)
+// newFlagSet returns a flag set which reports the errors instead of exiting
+func newFlagSet(e *env, name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(e.stderr)
+	return fs
+}
 
-		}(idx, results, b, &wg)
+// parseFlags parses the flags and returns the exit code if the command shouldn't continue
+func parseFlags(fs *flag.FlagSet, args []string) (int, bool) {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK, false
+		}
+		return exitUsage, false
 	}
+	return exitOK, true
+}
 
-	wg.Wait()
-	return len(boards)
+// options keeps the flags shared between the commands, each command registers only the flags it uses
+type options struct {
+	format       string
+	output       string
+	timeout      time.Duration
+	workers      int
+	noBacktrack  bool
+	onlyUnsolved bool
+	backend      string
 }
 
-func main() {
+func (o *options) inputFlags(fs *flag.FlagSet) {
+	fs.StringVar(&o.format, "format", "auto", "input format: auto, line, grid, sdk, sdm, ss or json")
+}
 
-	boards, parseErr := solver.ParseFile("./data/top95.txt")
-	if parseErr != nil {
-		log.Fatal(parseErr)
-	}
+func (o *options) outputFlag(fs *flag.FlagSet, value string, formats string) {
+	fs.StringVar(&o.output, "output", value, "output format: "+formats)
+}
 
-	begin := time.Now()
-	total := 0
-	workers := runtime.NumCPU()
+func (o *options) workerFlags(fs *flag.FlagSet) {
+	fs.IntVar(&o.workers, "workers", runtime.NumCPU(), "number of boards processed in parallel")
+}
 
-	fmt.Printf("Number of workers: %d\n", workers)
-	fmt.Printf("Number of boards parsed %d\n", len(boards))
+func (o *options) solveFlags(fs *flag.FlagSet) {
+	fs.DurationVar(&o.timeout, "timeout", 0, "time limit for each board, e.g. 500ms; 0 means no limit")
+	fs.BoolVar(&o.noBacktrack, "no-backtrack", false, "stop when the strategies stall instead of backtracking")
+}
+
+func (o *options) backendFlag(fs *flag.FlagSet) {
+	fs.StringVar(&o.backend, "backend", solver.BitBoardBackend.String(), "search backend: bitboard, backtrack or dlx")
+}
 
-	result := &Results{
-		Solutions: make(map[int]*solver.SolveResponse),
-		Mutex:     sync.Mutex{},
+// readPuzzles reads the puzzles of the given files or stdin. The format of a file is picked from its extension
+// when the format flag is auto and it can't be detected otherwise
+func readPuzzles(e *env, format string, paths []string) ([]*solver.Puzzle, error) {
+	selected, err := solver.ParseFormat(format)
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		paths = []string{"-"}
+	}
+	puzzles := make([]*solver.Puzzle, 0)
+	for _, path := range paths {
+		read, err := readPuzzleFile(e, selected, path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		puzzles = append(puzzles, read...)
 	}
+	return puzzles, nil
+}
 
-	batch := make(map[int]*solver.Board)
-	for idx, board := range boards {
-		if len(batch) == workers {
-			// Let's solve the current batch
-			solved := SolveBoardsInBatch(result, batch)
-			total += solved
-			// Clearing the buffer
-			clear(batch)
-			fmt.Printf("%d of %d boards have been processed\n", total, len(boards))
-			// Adding the current element
-			batch[idx] = board
-		} else {
-			batch[idx] = board
+func readPuzzleFile(e *env, format solver.Format, path string) ([]*solver.Puzzle, error) {
+	if path == "-" {
+		return solver.ReadPuzzles(e.stdin, format)
+	}
+	if fromPath, ok := solver.FormatFromPath(path); ok && format == solver.AutoFormat {
+		format = fromPath
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func(file *os.File) {
+		_ = file.Close()
+	}(file)
+	return solver.ReadPuzzles(file, format)
+}
+
+// newBoards returns the boards of the puzzles, the invalid puzzles are reported on stderr and left nil
+func newBoards(e *env, puzzles []*solver.Puzzle) ([]*solver.Board, bool) {
+	boards := make([]*solver.Board, len(puzzles))
+	failed := false
+	for i, puzzle := range puzzles {
+		board, err := puzzle.Board()
+		if err != nil {
+			_, _ = fmt.Fprintf(e.stderr, "sudoku: puzzle %d: %s\n", i+1, err.Error())
+			failed = true
+			continue
 		}
+		boards[i] = board
 	}
+	return boards, failed
+}
 
-	// Checking the remaining
-	if len(batch) > 0 {
-		solved := SolveBoardsInBatch(result, batch)
-		total += solved
+// loadBoards reads the input and returns its boards, the exit code is returned if the command shouldn't continue
+func loadBoards(e *env, o *options, paths []string) ([]*solver.Board, bool, int, bool) {
+	puzzles, err := readPuzzles(e, o.format, paths)
+	if err != nil {
+		_, _ = fmt.Fprintf(e.stderr, "sudoku: %s\n", err.Error())
+		return nil, false, exitInput, false
 	}
+	boards, failed := newBoards(e, puzzles)
+	return boards, failed, exitOK, true
+}
 
-	// Printing all results
-	result.PrintAll()
+// forEach calls fn for each index below n with the given number of workers
+func forEach(n int, workers int, fn func(index int)) {
+	workers = max(1, min(workers, n))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				fn(index)
+			}
+		}()
+	}
+	for index := 0; index < n; index++ {
+		indexes <- index
+	}
+	close(indexes)
+	wg.Wait()
+}
 
-	fmt.Printf("Number of unsolved boards %d\n", result.NumberOfUnsolved())
-	fmt.Printf("%d boards have been solved in parallel in %.2f seconds\n", total, time.Since(begin).Seconds())
+func exitCode(failed bool) int {
+	if failed {
+		return exitFailure
+	}
+	return exitOK
 }
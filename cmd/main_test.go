@@ -0,0 +1,91 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const (
+	easyBoard      = "003020600900305001001806400008102900700000008006708200002609500800203009005010300"
+	ambiguousBoard = "48392165796734582125187649354813297672956413813679824537268951481425..6969541..82"
+)
+
+func runCLI(t *testing.T, stdin string, args ...string) (string, string, int) {
+	t.Helper()
+	var stdout, stderr strings.Builder
+	code := run(args, strings.NewReader(stdin), &stdout, &stderr)
+	return stdout.String(), stderr.String(), code
+}
+
+func TestSolveReadsStdinAndWritesNDJSON(t *testing.T) {
+	stdout, stderr, code := runCLI(t, easyBoard+"\n", "solve", "--output", "ndjson", "--timeout", "5s")
+	if code != exitOK {
+		t.Fatalf("solve exit code = %d, want %d; stderr = %s", code, exitOK, stderr)
+	}
+	var record struct {
+		Initial  string `json:"initial"`
+		Solution string `json:"solution"`
+		Solved   bool   `json:"solved"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &record); err != nil {
+		t.Fatalf("Unmarshal() error = %v; stdout = %s", err, stdout)
+	}
+	if !record.Solved || record.Initial != strings.ReplaceAll(easyBoard, "0", ".") || strings.Contains(record.Solution, ".") {
+		t.Fatalf("solve record = %+v", record)
+	}
+
+	stdout, _, code = runCLI(t, easyBoard+"\n", "solve", "--only-unsolved", "--output", "csv")
+	if code != exitOK || strings.Count(stdout, "\n") != 1 {
+		t.Fatalf("solve --only-unsolved exit code = %d, output = %q, want only the CSV header", code, stdout)
+	}
+}
+
+func TestCommandsReturnExitCodes(t *testing.T) {
+	tests := []struct {
+		name  string
+		stdin string
+		args  []string
+		code  int
+	}{
+		{name: "no command", args: []string{}, code: exitUsage},
+		{name: "unknown command", args: []string{"frob"}, code: exitUsage},
+		{name: "unknown flag", args: []string{"solve", "--frob"}, code: exitUsage},
+		{name: "unknown output", stdin: easyBoard, args: []string{"rate", "--output", "yaml"}, code: exitUsage},
+		{name: "unreadable input", stdin: "not a board\n", args: []string{"solve"}, code: exitInput},
+		{name: "missing file", args: []string{"count", "does-not-exist.txt"}, code: exitInput},
+		{name: "ambiguous board", stdin: easyBoard + "\n" + ambiguousBoard + "\n", args: []string{"validate"}, code: exitFailure},
+		{name: "unique boards", stdin: easyBoard + "\n", args: []string{"validate", "--output", "json"}, code: exitOK},
+		{name: "count", stdin: ambiguousBoard + "\n", args: []string{"count", "--limit", "2"}, code: exitOK},
+		{name: "count with dlx", stdin: ambiguousBoard + "\n", args: []string{"count", "--backend", "dlx"}, code: exitOK},
+		{name: "unknown backend", stdin: easyBoard + "\n", args: []string{"solve", "--backend", "frob"}, code: exitUsage},
+		{name: "hint", stdin: easyBoard + "\n", args: []string{"hint"}, code: exitOK},
+		{name: "help", args: []string{"help"}, code: exitOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, stderr, code := runCLI(t, tt.stdin, tt.args...)
+			if code != tt.code {
+				t.Fatalf("exit code = %d, want %d; stderr = %s", code, tt.code, stderr)
+			}
+		})
+	}
+}
+
+func TestConvertAndGenerateWritePuzzleFormats(t *testing.T) {
+	stdout, stderr, code := runCLI(t, easyBoard+"\n", "convert", "--output", "sdk")
+	if code != exitOK {
+		t.Fatalf("convert exit code = %d; stderr = %s", code, stderr)
+	}
+	stdout, stderr, code = runCLI(t, stdout, "convert", "--format", "sdk", "--output", "sdm")
+	if code != exitOK || strings.TrimSpace(stdout) != easyBoard {
+		t.Fatalf("convert back = %q, exit code %d; stderr = %s", stdout, code, stderr)
+	}
+
+	first, _, code := runCLI(t, "", "generate", "--seed", "7", "--difficulty", "medium", "--count", "2")
+	second, _, _ := runCLI(t, "", "generate", "--seed", "7", "--difficulty", "medium", "--count", "2")
+	if code != exitOK || first != second || strings.Count(first, "\n") != 2 {
+		t.Fatalf("generate output = %q, want two reproducible puzzles", first)
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+const tableFormats = "text, json, ndjson or csv"
+
+// row is a result row of the commands other than solve, it is encoded with its json tags for the JSON outputs
+type row interface {
+	values() []string
+}
+
+// writeTable writes the rows in the given output format with the given column names
+func writeTable(w io.Writer, format string, columns []string, rows []row) error {
+	switch strings.ToLower(format) {
+	case "text":
+		writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(writer, strings.ToUpper(strings.Join(columns, "\t")))
+		for _, r := range rows {
+			_, _ = fmt.Fprintln(writer, strings.Join(r.values(), "\t"))
+		}
+		return writer.Flush()
+	case "csv":
+		writer := csv.NewWriter(w)
+		if err := writer.Write(columns); err != nil {
+			return err
+		}
+		for _, r := range rows {
+			if err := writer.Write(r.values()); err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+	case "json":
+		data, err := json.MarshalIndent(rows, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	case "ndjson":
+		encoder := json.NewEncoder(w)
+		for _, r := range rows {
+			if err := encoder.Encode(r); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown output format: %q", format)
+}
+
+// validTableFormat reports whether the format can be written by writeTable
+func validTableFormat(format string) bool {
+	switch strings.ToLower(format) {
+	case "text", "csv", "json", "ndjson":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"runtime"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/chasankm/sudoku-solver/pkg/rpc"
+	"github.com/chasankm/sudoku-solver/pkg/server"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "listen address")
+	grpcAddr := flag.String("grpc-addr", "", "listen address of the gRPC service, disabled when empty")
+	timeout := flag.Duration("timeout", 10*time.Second, "time limit for the work of each request")
+	maxConcurrent := flag.Int("max-concurrent", runtime.NumCPU(), "number of requests solved at the same time")
+	maxBody := flag.Int64("max-body", 1<<20, "maximum request body size in bytes")
+	shutdownTimeout := flag.Duration("shutdown-timeout", 15*time.Second, "time to finish the open requests on shutdown")
+	flag.Parse()
+
+	handler, err := server.New(server.Config{
+		Timeout:       *timeout,
+		MaxConcurrent: *maxConcurrent,
+		MaxBodyBytes:  *maxBody,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	httpServer := &http.Server{
+		Addr:              *addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       *timeout,
+		WriteTimeout:      *timeout + 5*time.Second,
+	}
+
+	var grpcServer *grpc.Server
+	if *grpcAddr != "" {
+		grpcServer, err = serveGRPC(*grpcAddr)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	shutdown := make(chan struct{})
+	go func() {
+		defer close(shutdown)
+		<-ctx.Done()
+		// Failing the readiness first lets the load balancers stop routing before the listener closes
+		handler.SetReady(false)
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), *shutdownTimeout)
+		defer cancel()
+		if grpcServer != nil {
+			stopGRPC(shutdownCtx, grpcServer)
+		}
+		if sErr := httpServer.Shutdown(shutdownCtx); sErr != nil {
+			log.Printf("Error shutting down: %s", sErr.Error())
+		}
+	}()
+
+	log.Printf("Listening on %s", *addr)
+	if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatal(err)
+	}
+	<-shutdown
+}
+
+// serveGRPC starts the gRPC service on the given address in the background
+func serveGRPC(addr string) (*grpc.Server, error) {
+	service, err := rpc.NewServer(rpc.Config{})
+	if err != nil {
+		return nil, err
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	grpcServer := grpc.NewServer()
+	service.Register(grpcServer)
+	go func() {
+		log.Printf("Serving gRPC on %s", addr)
+		if sErr := grpcServer.Serve(listener); sErr != nil {
+			log.Printf("Error serving gRPC: %s", sErr.Error())
+		}
+	}()
+	return grpcServer, nil
+}
+
+// stopGRPC waits for the open calls until the context is done, then closes them
+func stopGRPC(ctx context.Context, grpcServer *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		grpcServer.GracefulStop()
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		grpcServer.Stop()
+	}
+}
@@ -0,0 +1,184 @@
+package generator
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+
+	"github.com/chasankm/sudoku-solver/pkg/solver"
+)
+
+const defaultMaxAttempts = 100
+
+// Symmetry is the symmetry kept between the givens while they are removed from the full grid
+type Symmetry int
+
+const (
+	NoSymmetry Symmetry = iota
+	Rotational
+	Mirror
+	Diagonal
+)
+
+var Symmetries = map[Symmetry]string{
+	NoSymmetry: "None",
+	Rotational: "Rotational",
+	Mirror:     "Mirror",
+	Diagonal:   "Diagonal",
+}
+
+func (s Symmetry) String() string {
+	return Symmetries[s]
+}
+
+// ParseSymmetry returns the symmetry with the given name ignoring the case, e.g. "rotational"
+func ParseSymmetry(name string) (Symmetry, error) {
+	for symmetry, symmetryName := range Symmetries {
+		if strings.EqualFold(name, symmetryName) {
+			return symmetry, nil
+		}
+	}
+	return NoSymmetry, fmt.Errorf("unknown symmetry: %q", name)
+}
+
+// Options is the configuration of the generator. The same options always generate the same puzzle
+type Options struct {
+	Seed        uint64
+	Symmetry    Symmetry
+	Difficulty  solver.Difficulty
+	MaxAttempts int
+}
+
+// Puzzle is a generated puzzle with its unique solution
+type Puzzle struct {
+	Board      *solver.Board
+	Givens     [solver.BoardSize][solver.BoardSize]solver.Value
+	Solution   [solver.BoardSize][solver.BoardSize]solver.Value
+	Difficulty solver.Difficulty
+	Symmetry   Symmetry
+	Seed       uint64
+}
+
+// Generate builds a random full grid and removes the givens while the puzzle keeps a unique solution and doesn't get
+// harder than the target difficulty. Attempts are repeated until a puzzle with exactly the target difficulty is found
+func Generate(options Options) (*Puzzle, error) {
+	if _, ok := solver.Levels[options.Difficulty]; !ok {
+		return nil, fmt.Errorf("unknown difficulty: %d", options.Difficulty)
+	}
+	if _, ok := Symmetries[options.Symmetry]; !ok {
+		return nil, fmt.Errorf("unknown symmetry: %d", options.Symmetry)
+	}
+	attempts := options.MaxAttempts
+	if attempts <= 0 {
+		attempts = defaultMaxAttempts
+	}
+
+	rng := rand.New(rand.NewPCG(options.Seed, options.Seed))
+	for attempt := 0; attempt < attempts; attempt++ {
+		solution := solver.RandomGrid(rng)
+		givens, difficulty, err := removeGivens(solution, options, rng)
+		if err != nil {
+			return nil, err
+		}
+		if difficulty != options.Difficulty {
+			continue
+		}
+		board, err := solver.NewBoard(givens)
+		if err != nil {
+			return nil, err
+		}
+		return &Puzzle{
+			Board:      board,
+			Givens:     givens,
+			Solution:   solution,
+			Difficulty: difficulty,
+			Symmetry:   options.Symmetry,
+			Seed:       options.Seed,
+		}, nil
+	}
+	return nil, fmt.Errorf("unable to generate a %s puzzle in %d attempts", solver.Levels[options.Difficulty], attempts)
+}
+
+// removeGivens removes the symmetric cell groups in random order, a group is put back if the puzzle loses its unique
+// solution, gets harder than the target difficulty or can't be finished without backtracking
+func removeGivens(solution [solver.BoardSize][solver.BoardSize]solver.Value, options Options, rng *rand.Rand) ([solver.BoardSize][solver.BoardSize]solver.Value, solver.Difficulty, error) {
+	givens := solution
+	difficulty := solver.Easy
+	for _, group := range symmetryGroups(options.Symmetry, rng) {
+		removed := givens
+		for _, id := range group {
+			removed[id/solver.BoardSize][id%solver.BoardSize] = solver.EmptyCellValue
+		}
+		board, err := solver.NewBoard(removed)
+		if err != nil {
+			// Not enough givens are left to remove the group
+			continue
+		}
+		if !solver.HasUniqueSolution(board) {
+			continue
+		}
+		rating, err := rate(removed)
+		if err != nil {
+			return givens, difficulty, err
+		}
+		if rating.BackTrackingUsed || rating.Difficulty > options.Difficulty {
+			continue
+		}
+		givens = removed
+		difficulty = rating.Difficulty
+	}
+	return givens, difficulty, nil
+}
+
+// symmetryGroups returns the cell id groups which have to be removed together to keep the symmetry, in random order
+func symmetryGroups(symmetry Symmetry, rng *rand.Rand) [][]int {
+	groups := make([][]int, 0, solver.BoardSize*solver.BoardSize)
+	seen := make(map[int]struct{}, solver.BoardSize*solver.BoardSize)
+	for _, id := range rng.Perm(solver.BoardSize * solver.BoardSize) {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		group := []int{id}
+		if mirrored := mirror(symmetry, id); mirrored != id {
+			group = append(group, mirrored)
+		}
+		for _, member := range group {
+			seen[member] = struct{}{}
+		}
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// mirror returns the id of the cell which is symmetric to the given cell
+func mirror(symmetry Symmetry, id int) int {
+	row, col := id/solver.BoardSize, id%solver.BoardSize
+	last := solver.BoardSize - 1
+	switch symmetry {
+	case Rotational:
+		return (last-row)*solver.BoardSize + (last - col)
+	case Mirror:
+		return row*solver.BoardSize + (last - col)
+	case Diagonal:
+		return col*solver.BoardSize + row
+	}
+	return id
+}
+
+// Grade returns the difficulty of the puzzle from the strategy based rating of the solver
+func Grade(givens [solver.BoardSize][solver.BoardSize]solver.Value) (solver.Difficulty, error) {
+	rating, err := rate(givens)
+	if err != nil {
+		return solver.Evil, err
+	}
+	return rating.Difficulty, nil
+}
+
+// rate returns the strategy based rating of the puzzle
+func rate(givens [solver.BoardSize][solver.BoardSize]solver.Value) (*solver.Rating, error) {
+	board, err := solver.NewBoard(givens)
+	if err != nil {
+		return nil, err
+	}
+	return solver.Rate(board)
+}
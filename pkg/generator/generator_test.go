@@ -0,0 +1,74 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/chasankm/sudoku-solver/pkg/solver"
+)
+
+func TestGenerateIsReproducibleFromSeed(t *testing.T) {
+	options := Options{Seed: 42, Symmetry: Rotational, Difficulty: solver.Easy}
+	first, err := Generate(options)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	second, err := Generate(options)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if first.Givens != second.Givens {
+		t.Fatal("Generate() produced different puzzles from the same seed")
+	}
+}
+
+func TestGenerateKeepsSymmetryUniquenessAndDifficulty(t *testing.T) {
+	for _, symmetry := range []Symmetry{NoSymmetry, Rotational, Mirror, Diagonal} {
+		t.Run(symmetry.String(), func(t *testing.T) {
+			puzzle, err := Generate(Options{Seed: 7, Symmetry: symmetry, Difficulty: solver.Medium})
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+			if !solver.HasUniqueSolution(puzzle.Board) {
+				t.Fatal("generated puzzle doesn't have a unique solution")
+			}
+			if difficulty, _ := Grade(puzzle.Givens); difficulty != solver.Medium {
+				t.Fatalf("generated puzzle difficulty = %s, want Medium", solver.Levels[difficulty])
+			}
+			for id := 0; id < solver.BoardSize*solver.BoardSize; id++ {
+				mirrored := mirror(symmetry, id)
+				given := puzzle.Givens[id/solver.BoardSize][id%solver.BoardSize] != solver.EmptyCellValue
+				mirroredGiven := puzzle.Givens[mirrored/solver.BoardSize][mirrored%solver.BoardSize] != solver.EmptyCellValue
+				if given != mirroredGiven {
+					t.Fatalf("cell %d and its mirror %d break the %s symmetry", id, mirrored, symmetry)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateReachesEveryLevelWithoutBackTracking(t *testing.T) {
+	for difficulty := solver.Easy; difficulty <= solver.Evil; difficulty++ {
+		t.Run(solver.Levels[difficulty], func(t *testing.T) {
+			puzzle, err := Generate(Options{Seed: 3, Difficulty: difficulty})
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+			rating, err := solver.Rate(puzzle.Board)
+			if err != nil {
+				t.Fatalf("Rate() error = %v", err)
+			}
+			if rating.BackTrackingUsed || rating.Difficulty != difficulty {
+				t.Fatalf("generated puzzle rating = %+v, want %s without backtracking", rating, solver.Levels[difficulty])
+			}
+		})
+	}
+}
+
+func TestParseSymmetry(t *testing.T) {
+	if symmetry, err := ParseSymmetry("ROTATIONAL"); err != nil || symmetry != Rotational {
+		t.Fatalf("ParseSymmetry(ROTATIONAL) = %s, %v", symmetry, err)
+	}
+	if _, err := ParseSymmetry("spiral"); err == nil {
+		t.Fatal("ParseSymmetry() expected to reject an unknown name")
+	}
+}
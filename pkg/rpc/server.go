@@ -0,0 +1,338 @@
+// Package rpc serves the solver over gRPC with the SudokuService of proto/sudoku/v1/sudoku.proto
+package rpc
+
+//go:generate protoc --proto_path=../../proto --go_out=../.. --go_opt=module=github.com/chasankm/sudoku-solver --go-grpc_out=../.. --go-grpc_opt=module=github.com/chasankm/sudoku-solver sudoku/v1/sudoku.proto
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/chasankm/sudoku-solver/pkg/generator"
+	"github.com/chasankm/sudoku-solver/pkg/rpc/sudokupb"
+	"github.com/chasankm/sudoku-solver/pkg/solver"
+)
+
+const defaultMaxBatchSize = 10000
+
+// Config is the configuration of the server, the zero values are replaced by the defaults
+type Config struct {
+	// Solver solves and rates the boards, the request options derive a variant of it. The default pipeline is used
+	// when it is nil
+	Solver *solver.Solver
+	// MaxBatchSize limits the puzzles of a SolveBatch request, 10000 by default
+	MaxBatchSize int
+}
+
+// Server implements sudokupb.SudokuServiceServer
+type Server struct {
+	sudokupb.UnimplementedSudokuServiceServer
+	config Config
+}
+
+// NewServer returns a server with the given configuration
+func NewServer(config Config) (*Server, error) {
+	if config.Solver == nil {
+		s, err := solver.New()
+		if err != nil {
+			return nil, err
+		}
+		config.Solver = s
+	}
+	if config.MaxBatchSize <= 0 {
+		config.MaxBatchSize = defaultMaxBatchSize
+	}
+	return &Server{config: config}, nil
+}
+
+// Register registers the service of the server on the given gRPC server
+func (s *Server) Register(registrar grpc.ServiceRegistrar) {
+	sudokupb.RegisterSudokuServiceServer(registrar, s)
+}
+
+// Solve solves a single puzzle, a solve failure is returned in the response rather than as a status
+func (s *Server) Solve(ctx context.Context, request *sudokupb.SolveRequest) (*sudokupb.SolveResponse, error) {
+	pipeline, err := s.pipeline(request.GetOptions())
+	if err != nil {
+		return nil, err
+	}
+	board, err := parseBoard(request.GetPuzzle())
+	if err != nil {
+		return nil, err
+	}
+	response := pipeline.SolveContext(ctx, board)
+	var canceled *solver.CanceledError
+	if errors.As(response.Error, &canceled) {
+		return nil, statusError(response.Error)
+	}
+	return newSolveResponse(response.Record()), nil
+}
+
+// Rate rates a puzzle by the strategies it needs
+func (s *Server) Rate(ctx context.Context, request *sudokupb.RateRequest) (*sudokupb.RateResponse, error) {
+	board, err := parseBoard(request.GetPuzzle())
+	if err != nil {
+		return nil, err
+	}
+	rating, err := s.config.Solver.RateContext(ctx, board)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	counts := make(map[string]int32, len(rating.StrategyCounts))
+	for strategy, count := range rating.StrategyCounts {
+		counts[strategy.String()] = int32(count)
+	}
+	return &sudokupb.RateResponse{
+		Score:            rating.Score,
+		Difficulty:       solver.Levels[rating.Difficulty],
+		HardestStrategy:  rating.HardestStrategy.String(),
+		StrategyCounts:   counts,
+		BacktrackingUsed: rating.BackTrackingUsed,
+	}, nil
+}
+
+// Hint returns the easiest deduction on a puzzle, FailedPrecondition when the puzzle is solved or has no deduction
+func (s *Server) Hint(_ context.Context, request *sudokupb.HintRequest) (*sudokupb.HintResponse, error) {
+	pipeline, err := s.pipeline(request.GetOptions())
+	if err != nil {
+		return nil, err
+	}
+	board, err := parseBoard(request.GetPuzzle())
+	if err != nil {
+		return nil, err
+	}
+	hint, err := pipeline.Hint(board)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	response := &sudokupb.HintResponse{
+		Strategy:     hint.Strategy.String(),
+		Pattern:      solver.RefStrings(hint.Pattern),
+		Digits:       digits(hint.Digits),
+		Placements:   make([]*sudokupb.Placement, 0, len(hint.Placements)),
+		Eliminations: make([]*sudokupb.Elimination, 0, len(hint.Eliminations)),
+		Targets:      solver.RefStrings(hint.Targets),
+		Explanation:  hint.Explanation,
+		Fins:         solver.RefStrings(hint.Fins),
+		Clusters:     make([]*sudokupb.ColorCluster, 0, len(hint.Clusters)),
+	}
+	for _, cluster := range hint.Clusters {
+		response.Clusters = append(response.Clusters, &sudokupb.ColorCluster{First: solver.RefStrings(cluster.Colors[0]), Second: solver.RefStrings(cluster.Colors[1])})
+	}
+	for _, placement := range hint.Placements {
+		response.Placements = append(response.Placements, &sudokupb.Placement{Cell: placement.Cell.String(), Value: int32(placement.Value)})
+	}
+	for _, removed := range hint.Eliminations {
+		response.Eliminations = append(response.Eliminations, &sudokupb.Elimination{Cell: removed.Cell.String(), Digits: digits(removed.Marks)})
+	}
+	return response, nil
+}
+
+// SolveBatch solves the puzzles with solver.SolveAll and streams a response as each puzzle finishes. An invalid
+// puzzle doesn't fail the batch, its response is streamed first with the error
+func (s *Server) SolveBatch(request *sudokupb.SolveBatchRequest, stream grpc.ServerStreamingServer[sudokupb.SolveBatchResponse]) error {
+	if len(request.GetPuzzles()) > s.config.MaxBatchSize {
+		return status.Errorf(codes.InvalidArgument, "batch should contain at most %d puzzles, found %d", s.config.MaxBatchSize, len(request.GetPuzzles()))
+	}
+	pipeline, err := s.pipeline(request.GetOptions())
+	if err != nil {
+		return err
+	}
+	var timeout time.Duration
+	if request.GetBoardTimeout() != nil {
+		if err := request.GetBoardTimeout().CheckValid(); err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid board timeout: %s", err.Error())
+		}
+		timeout = request.GetBoardTimeout().AsDuration()
+	}
+
+	// indexes maps the position of each valid board to the position of its puzzle in the request
+	boards := make([]*solver.Board, 0, len(request.GetPuzzles()))
+	indexes := make([]int, 0, len(request.GetPuzzles()))
+	for index, puzzle := range request.GetPuzzles() {
+		board, err := readBoard(puzzle)
+		if err != nil {
+			record := &solver.SolveRecord{Strategies: []string{}, Error: solver.NewErrorInfo(err)}
+			if sErr := stream.Send(&sudokupb.SolveBatchResponse{Index: int32(index), Response: newSolveResponse(record)}); sErr != nil {
+				return sErr
+			}
+			continue
+		}
+		boards = append(boards, board)
+		indexes = append(indexes, index)
+	}
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	results := solver.SolveAll(ctx, boards, solver.BatchOptions{
+		Solver:  pipeline,
+		Workers: int(request.GetWorkers()),
+		Timeout: timeout,
+		Ordered: request.GetOrdered(),
+	})
+	var sendErr error
+	for result := range results {
+		if sendErr != nil {
+			// The results channel has to be drained, the cancelled boards finish quickly
+			continue
+		}
+		response := &sudokupb.SolveBatchResponse{Index: int32(indexes[result.Index]), Response: newSolveResponse(result.Response.Record())}
+		if sendErr = stream.Send(response); sendErr != nil {
+			cancel()
+		}
+	}
+	if sendErr != nil {
+		return sendErr
+	}
+	if err := stream.Context().Err(); err != nil {
+		return status.FromContextError(err).Err()
+	}
+	return nil
+}
+
+// Generate generates a puzzle with a unique solution
+func (s *Server) Generate(_ context.Context, request *sudokupb.GenerateRequest) (*sudokupb.GenerateResponse, error) {
+	options := generator.Options{Difficulty: solver.Easy, Seed: request.GetSeed()}
+	if options.Seed == 0 {
+		options.Seed = uint64(time.Now().UnixNano())
+	}
+	if name := request.GetDifficulty(); name != "" {
+		difficulty, err := solver.ParseDifficulty(name)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		options.Difficulty = difficulty
+	}
+	if name := request.GetSymmetry(); name != "" {
+		symmetry, err := generator.ParseSymmetry(name)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		options.Symmetry = symmetry
+	}
+	puzzle, err := generator.Generate(options)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &sudokupb.GenerateResponse{
+		Puzzle:     solver.GridString(puzzle.Givens),
+		Solution:   solver.GridString(puzzle.Solution),
+		Difficulty: solver.Levels[puzzle.Difficulty],
+		Symmetry:   puzzle.Symmetry.String(),
+		Seed:       puzzle.Seed,
+	}, nil
+}
+
+// pipeline returns the solver of the given options, the configured solver when they don't change it
+func (s *Server) pipeline(options *sudokupb.SolveOptions) (*solver.Solver, error) {
+	if len(options.GetStrategies()) == 0 && !options.GetNoBacktrack() {
+		return s.config.Solver, nil
+	}
+	var solverOptions []solver.Option
+	if len(options.GetStrategies()) > 0 {
+		names := make([]solver.StrategyName, 0, len(options.GetStrategies()))
+		for _, name := range options.GetStrategies() {
+			names = append(names, solver.StrategyName(name))
+		}
+		solverOptions = append(solverOptions, solver.WithStrategies(names...))
+	}
+	if options.GetNoBacktrack() {
+		solverOptions = append(solverOptions, solver.WithoutBacktracking())
+	}
+	pipeline, err := s.config.Solver.With(solverOptions...)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return pipeline, nil
+}
+
+// readBoard reads the single puzzle of the given text in any format read by solver.ReadPuzzles
+func readBoard(text string) (*solver.Board, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, errors.New("puzzle is required")
+	}
+	puzzles, err := solver.ReadPuzzles(strings.NewReader(text), solver.AutoFormat)
+	if err != nil {
+		return nil, err
+	}
+	if len(puzzles) != 1 {
+		return nil, fmt.Errorf("request should contain exactly one puzzle, found %d", len(puzzles))
+	}
+	return puzzles[0].Board()
+}
+
+// parseBoard is readBoard with the errors converted to InvalidArgument
+func parseBoard(text string) (*solver.Board, error) {
+	board, err := readBoard(text)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return board, nil
+}
+
+// statusError converts the solver errors to the gRPC status errors
+func statusError(err error) error {
+	var canceled *solver.CanceledError
+	switch {
+	case errors.As(err, &canceled):
+		return status.FromContextError(canceled.Err).Err()
+	case errors.Is(err, solver.ErrAlreadySolved), errors.Is(err, solver.ErrNoDeduction):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	switch solver.NewErrorInfo(err).Type {
+	case solver.ParseErrorType, solver.ConflictErrorType, solver.TooFewGivensErrorType, solver.InvalidValueErrorType,
+		solver.EmptyCandidatesErrorType, solver.NoSolutionErrorType, solver.MultipleSolutionsErrorType:
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+func newSolveResponse(record *solver.SolveRecord) *sudokupb.SolveResponse {
+	return &sudokupb.SolveResponse{
+		Initial:          record.Initial,
+		Solution:         record.Solution,
+		Solved:           record.Solved,
+		Difficulty:       record.Difficulty,
+		ClueDifficulty:   record.ClueDifficulty,
+		Score:            record.Score,
+		HardestStrategy:  record.HardestStrategy,
+		Givens:           int32(record.Givens),
+		DurationNs:       record.DurationNs,
+		BacktrackingUsed: record.BackTrackingUsed,
+		Strategies:       record.Strategies,
+		Error:            newErrorInfo(record.Error),
+	}
+}
+
+func newErrorInfo(info *solver.ErrorInfo) *sudokupb.ErrorInfo {
+	if info == nil {
+		return nil
+	}
+	return &sudokupb.ErrorInfo{
+		Type:      string(info.Type),
+		Message:   info.Message,
+		Unit:      string(info.Unit),
+		UnitIndex: int32(info.UnitIndex),
+		Value:     int32(info.Value),
+		Cells:     info.Cells,
+		Strategy:  info.Strategy,
+		Count:     int32(info.Count),
+		Line:      int32(info.Line),
+		Column:    int32(info.Column),
+	}
+}
+
+func digits(set solver.CandidateSet) []int32 {
+	values := set.ToArray()
+	result := make([]int32, 0, len(values))
+	for _, value := range values {
+		result = append(result, int32(value))
+	}
+	return result
+}
@@ -0,0 +1,259 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/chasankm/sudoku-solver/pkg/rpc/sudokupb"
+	"github.com/chasankm/sudoku-solver/pkg/solver"
+)
+
+const (
+	easyBoard      = "003020600900305001001806400008102900700000008006708200002609500800203009005010300"
+	ambiguousBoard = "48392165796734582125187649354813297672956413813679824537268951481425..6969541..82"
+	conflictBoard  = "603020600900305001001806400008102900700000008006708200002609500800203009005010300"
+	solvedBoard    = "483921657967345821251876493548132976729564138136798245372689514814253769695417382"
+	hardBoard      = "48.3............71.2.......7.5....6....2..8.............1.76...3.....4......5...."
+)
+
+// newTestClient serves the server in-process over bufconn and returns a client connected to it
+func newTestClient(t *testing.T, config Config) sudokupb.SudokuServiceClient {
+	t.Helper()
+	s, err := NewServer(config)
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+	listener := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer()
+	s.Register(grpcServer)
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	return sudokupb.NewSudokuServiceClient(conn)
+}
+
+func TestUnaryRPCs(t *testing.T) {
+	client := newTestClient(t, Config{})
+	ctx := context.Background()
+
+	solved, err := client.Solve(ctx, &sudokupb.SolveRequest{Puzzle: easyBoard})
+	if err != nil {
+		t.Fatalf("Solve() error = %v", err)
+	}
+	if !solved.GetSolved() || len(solved.GetSolution()) != 81 || solved.GetError() != nil {
+		t.Fatalf("Solve() = %v, want a solved response", solved)
+	}
+
+	stalled, err := client.Solve(ctx, &sudokupb.SolveRequest{
+		Puzzle:  hardBoard,
+		Options: &sudokupb.SolveOptions{Strategies: []string{"Hidden Single"}, NoBacktrack: true},
+	})
+	if err != nil {
+		t.Fatalf("Solve() with options error = %v", err)
+	}
+	if stalled.GetSolved() || stalled.GetError().GetType() != "stalled" {
+		t.Fatalf("Solve() with only hidden singles = %v, want a stalled response", stalled)
+	}
+
+	rating, err := client.Rate(ctx, &sudokupb.RateRequest{Puzzle: easyBoard})
+	if err != nil || rating.GetDifficulty() == "" || rating.GetScore() == 0 || len(rating.GetStrategyCounts()) == 0 {
+		t.Fatalf("Rate() = %v, %v", rating, err)
+	}
+
+	hint, err := client.Hint(ctx, &sudokupb.HintRequest{Puzzle: easyBoard})
+	if err != nil || hint.GetStrategy() == "" || hint.GetExplanation() == "" {
+		t.Fatalf("Hint() = %v, %v", hint, err)
+	}
+	coloring, err := client.Hint(ctx, &sudokupb.HintRequest{
+		Puzzle:  "4.....8.5.3..........7......2.....6.....8.4......1.......6.3.7.5..2.....1.4......",
+		Options: &sudokupb.SolveOptions{Strategies: []string{"Coloring"}},
+	})
+	if err != nil || len(coloring.GetClusters()) != 1 || len(coloring.GetClusters()[0].GetFirst()) == 0 {
+		t.Fatalf("Hint() with coloring = %v, %v, want a color cluster", coloring, err)
+	}
+
+	first, err := client.Generate(ctx, &sudokupb.GenerateRequest{Difficulty: "medium", Symmetry: "rotational", Seed: 7})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	second, _ := client.Generate(ctx, &sudokupb.GenerateRequest{Difficulty: "medium", Symmetry: "rotational", Seed: 7})
+	if first.GetPuzzle() == "" || first.GetPuzzle() != second.GetPuzzle() || first.GetDifficulty() != "Medium" {
+		t.Fatalf("Generate() = %v and %v, want the same medium puzzle", first, second)
+	}
+}
+
+// probeStrategy is a custom strategy finding nothing, it records that the pipeline ran it
+type probeStrategy struct {
+	applied *atomic.Bool
+}
+
+func (s probeStrategy) Name() solver.StrategyName {
+	return "Probe"
+}
+
+func (s probeStrategy) Apply(*solver.Board) (bool, error) {
+	s.applied.Store(true)
+	return false, nil
+}
+
+func TestRPCsKeepConfiguredSolver(t *testing.T) {
+	probe := probeStrategy{applied: &atomic.Bool{}}
+	configured, err := solver.New(solver.WithStrategies(solver.HiddenSingleStrategy), solver.WithStrategy(probe), solver.WithBackend(solver.DLXBackend))
+	if err != nil {
+		t.Fatalf("solver.New() error = %v", err)
+	}
+	client := newTestClient(t, Config{Solver: configured})
+	ctx := context.Background()
+
+	stalled, err := client.Solve(ctx, &sudokupb.SolveRequest{Puzzle: hardBoard, Options: &sudokupb.SolveOptions{NoBacktrack: true}})
+	if err != nil {
+		t.Fatalf("Solve() with options error = %v", err)
+	}
+	if stalled.GetSolved() || stalled.GetError().GetType() != "stalled" || !probe.applied.Load() {
+		t.Fatalf("Solve() = %v, probe applied = %t, want a stall after the custom strategy ran", stalled, probe.applied.Load())
+	}
+
+	probe.applied.Store(false)
+	rating, err := client.Rate(ctx, &sudokupb.RateRequest{Puzzle: hardBoard})
+	if err != nil {
+		t.Fatalf("Rate() error = %v", err)
+	}
+	if !probe.applied.Load() || !rating.GetBacktrackingUsed() {
+		t.Fatalf("Rate() = %v, probe applied = %t, want the configured pipeline to rate the puzzle", rating, probe.applied.Load())
+	}
+}
+
+func TestStatusCodes(t *testing.T) {
+	client := newTestClient(t, Config{})
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		call func() error
+		code codes.Code
+	}{
+		{name: "invalid puzzle", call: func() error {
+			_, err := client.Solve(ctx, &sudokupb.SolveRequest{Puzzle: "12x"})
+			return err
+		}, code: codes.InvalidArgument},
+		{name: "conflict", call: func() error {
+			_, err := client.Rate(ctx, &sudokupb.RateRequest{Puzzle: conflictBoard})
+			return err
+		}, code: codes.InvalidArgument},
+		{name: "unknown strategy", call: func() error {
+			_, err := client.Solve(ctx, &sudokupb.SolveRequest{Puzzle: easyBoard, Options: &sudokupb.SolveOptions{Strategies: []string{"Guess"}}})
+			return err
+		}, code: codes.InvalidArgument},
+		{name: "solved hint", call: func() error {
+			_, err := client.Hint(ctx, &sudokupb.HintRequest{Puzzle: solvedBoard})
+			return err
+		}, code: codes.FailedPrecondition},
+		{name: "unknown difficulty", call: func() error {
+			_, err := client.Generate(ctx, &sudokupb.GenerateRequest{Difficulty: "impossible"})
+			return err
+		}, code: codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := status.Code(tt.call()); code != tt.code {
+				t.Fatalf("status code = %s, want %s", code, tt.code)
+			}
+		})
+	}
+
+	expired, cancel := context.WithTimeout(ctx, time.Nanosecond)
+	defer cancel()
+	<-expired.Done()
+	if _, err := client.Solve(expired, &sudokupb.SolveRequest{Puzzle: easyBoard}); status.Code(err) != codes.DeadlineExceeded {
+		t.Fatalf("Solve() with an expired deadline error = %v, want DeadlineExceeded", err)
+	}
+}
+
+func TestSolveBatchStreamsEveryPuzzle(t *testing.T) {
+	client := newTestClient(t, Config{})
+	puzzles := []string{easyBoard, "12x", ambiguousBoard, easyBoard}
+	stream, err := client.SolveBatch(context.Background(), &sudokupb.SolveBatchRequest{
+		Puzzles:      puzzles,
+		Workers:      2,
+		BoardTimeout: durationpb.New(time.Minute),
+		Ordered:      true,
+	})
+	if err != nil {
+		t.Fatalf("SolveBatch() error = %v", err)
+	}
+
+	seen := make(map[int32]*sudokupb.SolveResponse)
+	var order []int32
+	for {
+		response, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Recv() error = %v", err)
+		}
+		seen[response.GetIndex()] = response.GetResponse()
+		order = append(order, response.GetIndex())
+	}
+	if len(seen) != len(puzzles) {
+		t.Fatalf("SolveBatch() streamed indexes %v, want one response per puzzle", order)
+	}
+	if seen[1].GetError().GetType() != "parse" {
+		t.Fatalf("response of the invalid puzzle = %v, want a parse error", seen[1])
+	}
+	// The invalid puzzle is streamed first, the others in the input order
+	if want := []int32{1, 0, 2, 3}; !equal(order, want) {
+		t.Fatalf("SolveBatch() order = %v, want %v", order, want)
+	}
+	for _, index := range []int32{0, 2, 3} {
+		if !seen[index].GetSolved() {
+			t.Fatalf("response %d = %v, want solved", index, seen[index])
+		}
+	}
+
+	stream, err = newTestClient(t, Config{MaxBatchSize: 1}).SolveBatch(context.Background(), &sudokupb.SolveBatchRequest{Puzzles: puzzles})
+	if err != nil {
+		t.Fatalf("SolveBatch() error = %v", err)
+	}
+	if _, err := stream.Recv(); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("Recv() beyond the batch size error = %v, want InvalidArgument", err)
+	}
+}
+
+func equal(a []int32, b []int32) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
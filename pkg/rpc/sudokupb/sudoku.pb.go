@@ -0,0 +1,1288 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: sudoku/v1/sudoku.proto
+
+package sudokupb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// SolveOptions configures the strategy pipeline.
+type SolveOptions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// strategies replaces the default pipeline with the registered strategies of the given names, in order.
+	Strategies []string `protobuf:"bytes,1,rep,name=strategies,proto3" json:"strategies,omitempty"`
+	// no_backtrack stops when the strategies stall instead of falling back to backtracking.
+	NoBacktrack   bool `protobuf:"varint,2,opt,name=no_backtrack,json=noBacktrack,proto3" json:"no_backtrack,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SolveOptions) Reset() {
+	*x = SolveOptions{}
+	mi := &file_sudoku_v1_sudoku_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SolveOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SolveOptions) ProtoMessage() {}
+
+func (x *SolveOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_sudoku_v1_sudoku_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SolveOptions.ProtoReflect.Descriptor instead.
+func (*SolveOptions) Descriptor() ([]byte, []int) {
+	return file_sudoku_v1_sudoku_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SolveOptions) GetStrategies() []string {
+	if x != nil {
+		return x.Strategies
+	}
+	return nil
+}
+
+func (x *SolveOptions) GetNoBacktrack() bool {
+	if x != nil {
+		return x.NoBacktrack
+	}
+	return false
+}
+
+type SolveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Puzzle        string                 `protobuf:"bytes,1,opt,name=puzzle,proto3" json:"puzzle,omitempty"`
+	Options       *SolveOptions          `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SolveRequest) Reset() {
+	*x = SolveRequest{}
+	mi := &file_sudoku_v1_sudoku_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SolveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SolveRequest) ProtoMessage() {}
+
+func (x *SolveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sudoku_v1_sudoku_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SolveRequest.ProtoReflect.Descriptor instead.
+func (*SolveRequest) Descriptor() ([]byte, []int) {
+	return file_sudoku_v1_sudoku_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SolveRequest) GetPuzzle() string {
+	if x != nil {
+		return x.Puzzle
+	}
+	return ""
+}
+
+func (x *SolveRequest) GetOptions() *SolveOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+// ErrorInfo is the machine-readable form of an error, see solver.ErrorInfo.
+type ErrorInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Unit          string                 `protobuf:"bytes,3,opt,name=unit,proto3" json:"unit,omitempty"`
+	UnitIndex     int32                  `protobuf:"varint,4,opt,name=unit_index,json=unitIndex,proto3" json:"unit_index,omitempty"`
+	Value         int32                  `protobuf:"varint,5,opt,name=value,proto3" json:"value,omitempty"`
+	Cells         []string               `protobuf:"bytes,6,rep,name=cells,proto3" json:"cells,omitempty"`
+	Strategy      string                 `protobuf:"bytes,7,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	Count         int32                  `protobuf:"varint,8,opt,name=count,proto3" json:"count,omitempty"`
+	Line          int32                  `protobuf:"varint,9,opt,name=line,proto3" json:"line,omitempty"`
+	Column        int32                  `protobuf:"varint,10,opt,name=column,proto3" json:"column,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ErrorInfo) Reset() {
+	*x = ErrorInfo{}
+	mi := &file_sudoku_v1_sudoku_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ErrorInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErrorInfo) ProtoMessage() {}
+
+func (x *ErrorInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_sudoku_v1_sudoku_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErrorInfo.ProtoReflect.Descriptor instead.
+func (*ErrorInfo) Descriptor() ([]byte, []int) {
+	return file_sudoku_v1_sudoku_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ErrorInfo) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ErrorInfo) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ErrorInfo) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *ErrorInfo) GetUnitIndex() int32 {
+	if x != nil {
+		return x.UnitIndex
+	}
+	return 0
+}
+
+func (x *ErrorInfo) GetValue() int32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *ErrorInfo) GetCells() []string {
+	if x != nil {
+		return x.Cells
+	}
+	return nil
+}
+
+func (x *ErrorInfo) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *ErrorInfo) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *ErrorInfo) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *ErrorInfo) GetColumn() int32 {
+	if x != nil {
+		return x.Column
+	}
+	return 0
+}
+
+// SolveResponse is the result of a solve with the boards in the 81 character line format.
+type SolveResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Initial          string                 `protobuf:"bytes,1,opt,name=initial,proto3" json:"initial,omitempty"`
+	Solution         string                 `protobuf:"bytes,2,opt,name=solution,proto3" json:"solution,omitempty"`
+	Solved           bool                   `protobuf:"varint,3,opt,name=solved,proto3" json:"solved,omitempty"`
+	Difficulty       string                 `protobuf:"bytes,4,opt,name=difficulty,proto3" json:"difficulty,omitempty"`
+	ClueDifficulty   string                 `protobuf:"bytes,5,opt,name=clue_difficulty,json=clueDifficulty,proto3" json:"clue_difficulty,omitempty"`
+	Score            float64                `protobuf:"fixed64,6,opt,name=score,proto3" json:"score,omitempty"`
+	HardestStrategy  string                 `protobuf:"bytes,7,opt,name=hardest_strategy,json=hardestStrategy,proto3" json:"hardest_strategy,omitempty"`
+	Givens           int32                  `protobuf:"varint,8,opt,name=givens,proto3" json:"givens,omitempty"`
+	DurationNs       int64                  `protobuf:"varint,9,opt,name=duration_ns,json=durationNs,proto3" json:"duration_ns,omitempty"`
+	BacktrackingUsed bool                   `protobuf:"varint,10,opt,name=backtracking_used,json=backtrackingUsed,proto3" json:"backtracking_used,omitempty"`
+	Strategies       []string               `protobuf:"bytes,11,rep,name=strategies,proto3" json:"strategies,omitempty"`
+	Error            *ErrorInfo             `protobuf:"bytes,12,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *SolveResponse) Reset() {
+	*x = SolveResponse{}
+	mi := &file_sudoku_v1_sudoku_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SolveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SolveResponse) ProtoMessage() {}
+
+func (x *SolveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sudoku_v1_sudoku_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SolveResponse.ProtoReflect.Descriptor instead.
+func (*SolveResponse) Descriptor() ([]byte, []int) {
+	return file_sudoku_v1_sudoku_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SolveResponse) GetInitial() string {
+	if x != nil {
+		return x.Initial
+	}
+	return ""
+}
+
+func (x *SolveResponse) GetSolution() string {
+	if x != nil {
+		return x.Solution
+	}
+	return ""
+}
+
+func (x *SolveResponse) GetSolved() bool {
+	if x != nil {
+		return x.Solved
+	}
+	return false
+}
+
+func (x *SolveResponse) GetDifficulty() string {
+	if x != nil {
+		return x.Difficulty
+	}
+	return ""
+}
+
+func (x *SolveResponse) GetClueDifficulty() string {
+	if x != nil {
+		return x.ClueDifficulty
+	}
+	return ""
+}
+
+func (x *SolveResponse) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *SolveResponse) GetHardestStrategy() string {
+	if x != nil {
+		return x.HardestStrategy
+	}
+	return ""
+}
+
+func (x *SolveResponse) GetGivens() int32 {
+	if x != nil {
+		return x.Givens
+	}
+	return 0
+}
+
+func (x *SolveResponse) GetDurationNs() int64 {
+	if x != nil {
+		return x.DurationNs
+	}
+	return 0
+}
+
+func (x *SolveResponse) GetBacktrackingUsed() bool {
+	if x != nil {
+		return x.BacktrackingUsed
+	}
+	return false
+}
+
+func (x *SolveResponse) GetStrategies() []string {
+	if x != nil {
+		return x.Strategies
+	}
+	return nil
+}
+
+func (x *SolveResponse) GetError() *ErrorInfo {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type RateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Puzzle        string                 `protobuf:"bytes,1,opt,name=puzzle,proto3" json:"puzzle,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RateRequest) Reset() {
+	*x = RateRequest{}
+	mi := &file_sudoku_v1_sudoku_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateRequest) ProtoMessage() {}
+
+func (x *RateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sudoku_v1_sudoku_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateRequest.ProtoReflect.Descriptor instead.
+func (*RateRequest) Descriptor() ([]byte, []int) {
+	return file_sudoku_v1_sudoku_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RateRequest) GetPuzzle() string {
+	if x != nil {
+		return x.Puzzle
+	}
+	return ""
+}
+
+type RateResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Score            float64                `protobuf:"fixed64,1,opt,name=score,proto3" json:"score,omitempty"`
+	Difficulty       string                 `protobuf:"bytes,2,opt,name=difficulty,proto3" json:"difficulty,omitempty"`
+	HardestStrategy  string                 `protobuf:"bytes,3,opt,name=hardest_strategy,json=hardestStrategy,proto3" json:"hardest_strategy,omitempty"`
+	StrategyCounts   map[string]int32       `protobuf:"bytes,4,rep,name=strategy_counts,json=strategyCounts,proto3" json:"strategy_counts,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	BacktrackingUsed bool                   `protobuf:"varint,5,opt,name=backtracking_used,json=backtrackingUsed,proto3" json:"backtracking_used,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *RateResponse) Reset() {
+	*x = RateResponse{}
+	mi := &file_sudoku_v1_sudoku_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateResponse) ProtoMessage() {}
+
+func (x *RateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sudoku_v1_sudoku_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateResponse.ProtoReflect.Descriptor instead.
+func (*RateResponse) Descriptor() ([]byte, []int) {
+	return file_sudoku_v1_sudoku_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RateResponse) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *RateResponse) GetDifficulty() string {
+	if x != nil {
+		return x.Difficulty
+	}
+	return ""
+}
+
+func (x *RateResponse) GetHardestStrategy() string {
+	if x != nil {
+		return x.HardestStrategy
+	}
+	return ""
+}
+
+func (x *RateResponse) GetStrategyCounts() map[string]int32 {
+	if x != nil {
+		return x.StrategyCounts
+	}
+	return nil
+}
+
+func (x *RateResponse) GetBacktrackingUsed() bool {
+	if x != nil {
+		return x.BacktrackingUsed
+	}
+	return false
+}
+
+type HintRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Puzzle        string                 `protobuf:"bytes,1,opt,name=puzzle,proto3" json:"puzzle,omitempty"`
+	Options       *SolveOptions          `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HintRequest) Reset() {
+	*x = HintRequest{}
+	mi := &file_sudoku_v1_sudoku_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HintRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HintRequest) ProtoMessage() {}
+
+func (x *HintRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sudoku_v1_sudoku_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HintRequest.ProtoReflect.Descriptor instead.
+func (*HintRequest) Descriptor() ([]byte, []int) {
+	return file_sudoku_v1_sudoku_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *HintRequest) GetPuzzle() string {
+	if x != nil {
+		return x.Puzzle
+	}
+	return ""
+}
+
+func (x *HintRequest) GetOptions() *SolveOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type Placement struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cell          string                 `protobuf:"bytes,1,opt,name=cell,proto3" json:"cell,omitempty"`
+	Value         int32                  `protobuf:"varint,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Placement) Reset() {
+	*x = Placement{}
+	mi := &file_sudoku_v1_sudoku_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Placement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Placement) ProtoMessage() {}
+
+func (x *Placement) ProtoReflect() protoreflect.Message {
+	mi := &file_sudoku_v1_sudoku_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Placement.ProtoReflect.Descriptor instead.
+func (*Placement) Descriptor() ([]byte, []int) {
+	return file_sudoku_v1_sudoku_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Placement) GetCell() string {
+	if x != nil {
+		return x.Cell
+	}
+	return ""
+}
+
+func (x *Placement) GetValue() int32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+type Elimination struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cell          string                 `protobuf:"bytes,1,opt,name=cell,proto3" json:"cell,omitempty"`
+	Digits        []int32                `protobuf:"varint,2,rep,packed,name=digits,proto3" json:"digits,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Elimination) Reset() {
+	*x = Elimination{}
+	mi := &file_sudoku_v1_sudoku_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Elimination) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Elimination) ProtoMessage() {}
+
+func (x *Elimination) ProtoReflect() protoreflect.Message {
+	mi := &file_sudoku_v1_sudoku_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Elimination.ProtoReflect.Descriptor instead.
+func (*Elimination) Descriptor() ([]byte, []int) {
+	return file_sudoku_v1_sudoku_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Elimination) GetCell() string {
+	if x != nil {
+		return x.Cell
+	}
+	return ""
+}
+
+func (x *Elimination) GetDigits() []int32 {
+	if x != nil {
+		return x.Digits
+	}
+	return nil
+}
+
+// ColorCluster is a cluster of coloring, either every cell of the first color holds the digit or every cell of the
+// second one does.
+type ColorCluster struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	First         []string               `protobuf:"bytes,1,rep,name=first,proto3" json:"first,omitempty"`
+	Second        []string               `protobuf:"bytes,2,rep,name=second,proto3" json:"second,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ColorCluster) Reset() {
+	*x = ColorCluster{}
+	mi := &file_sudoku_v1_sudoku_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ColorCluster) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ColorCluster) ProtoMessage() {}
+
+func (x *ColorCluster) ProtoReflect() protoreflect.Message {
+	mi := &file_sudoku_v1_sudoku_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ColorCluster.ProtoReflect.Descriptor instead.
+func (*ColorCluster) Descriptor() ([]byte, []int) {
+	return file_sudoku_v1_sudoku_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ColorCluster) GetFirst() []string {
+	if x != nil {
+		return x.First
+	}
+	return nil
+}
+
+func (x *ColorCluster) GetSecond() []string {
+	if x != nil {
+		return x.Second
+	}
+	return nil
+}
+
+type HintResponse struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Strategy     string                 `protobuf:"bytes,1,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	Pattern      []string               `protobuf:"bytes,2,rep,name=pattern,proto3" json:"pattern,omitempty"`
+	Digits       []int32                `protobuf:"varint,3,rep,packed,name=digits,proto3" json:"digits,omitempty"`
+	Placements   []*Placement           `protobuf:"bytes,4,rep,name=placements,proto3" json:"placements,omitempty"`
+	Eliminations []*Elimination         `protobuf:"bytes,5,rep,name=eliminations,proto3" json:"eliminations,omitempty"`
+	Targets      []string               `protobuf:"bytes,6,rep,name=targets,proto3" json:"targets,omitempty"`
+	Explanation  string                 `protobuf:"bytes,7,opt,name=explanation,proto3" json:"explanation,omitempty"`
+	// fins are the fin cells of a finned fish.
+	Fins []string `protobuf:"bytes,8,rep,name=fins,proto3" json:"fins,omitempty"`
+	// clusters are the color clusters of a coloring.
+	Clusters      []*ColorCluster `protobuf:"bytes,9,rep,name=clusters,proto3" json:"clusters,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HintResponse) Reset() {
+	*x = HintResponse{}
+	mi := &file_sudoku_v1_sudoku_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HintResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HintResponse) ProtoMessage() {}
+
+func (x *HintResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sudoku_v1_sudoku_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HintResponse.ProtoReflect.Descriptor instead.
+func (*HintResponse) Descriptor() ([]byte, []int) {
+	return file_sudoku_v1_sudoku_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *HintResponse) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *HintResponse) GetPattern() []string {
+	if x != nil {
+		return x.Pattern
+	}
+	return nil
+}
+
+func (x *HintResponse) GetDigits() []int32 {
+	if x != nil {
+		return x.Digits
+	}
+	return nil
+}
+
+func (x *HintResponse) GetPlacements() []*Placement {
+	if x != nil {
+		return x.Placements
+	}
+	return nil
+}
+
+func (x *HintResponse) GetEliminations() []*Elimination {
+	if x != nil {
+		return x.Eliminations
+	}
+	return nil
+}
+
+func (x *HintResponse) GetTargets() []string {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+func (x *HintResponse) GetExplanation() string {
+	if x != nil {
+		return x.Explanation
+	}
+	return ""
+}
+
+func (x *HintResponse) GetFins() []string {
+	if x != nil {
+		return x.Fins
+	}
+	return nil
+}
+
+func (x *HintResponse) GetClusters() []*ColorCluster {
+	if x != nil {
+		return x.Clusters
+	}
+	return nil
+}
+
+type SolveBatchRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Puzzles []string               `protobuf:"bytes,1,rep,name=puzzles,proto3" json:"puzzles,omitempty"`
+	Options *SolveOptions          `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+	// workers is the number of puzzles solved in parallel, the number of CPUs when it is 0.
+	Workers int32 `protobuf:"varint,3,opt,name=workers,proto3" json:"workers,omitempty"`
+	// board_timeout limits the solve of each puzzle.
+	BoardTimeout *durationpb.Duration `protobuf:"bytes,4,opt,name=board_timeout,json=boardTimeout,proto3" json:"board_timeout,omitempty"`
+	// ordered streams the responses in the input order instead of the completion order.
+	Ordered       bool `protobuf:"varint,5,opt,name=ordered,proto3" json:"ordered,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SolveBatchRequest) Reset() {
+	*x = SolveBatchRequest{}
+	mi := &file_sudoku_v1_sudoku_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SolveBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SolveBatchRequest) ProtoMessage() {}
+
+func (x *SolveBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sudoku_v1_sudoku_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SolveBatchRequest.ProtoReflect.Descriptor instead.
+func (*SolveBatchRequest) Descriptor() ([]byte, []int) {
+	return file_sudoku_v1_sudoku_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SolveBatchRequest) GetPuzzles() []string {
+	if x != nil {
+		return x.Puzzles
+	}
+	return nil
+}
+
+func (x *SolveBatchRequest) GetOptions() *SolveOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *SolveBatchRequest) GetWorkers() int32 {
+	if x != nil {
+		return x.Workers
+	}
+	return 0
+}
+
+func (x *SolveBatchRequest) GetBoardTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.BoardTimeout
+	}
+	return nil
+}
+
+func (x *SolveBatchRequest) GetOrdered() bool {
+	if x != nil {
+		return x.Ordered
+	}
+	return false
+}
+
+type SolveBatchResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// index is the position of the puzzle in the request.
+	Index         int32          `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Response      *SolveResponse `protobuf:"bytes,2,opt,name=response,proto3" json:"response,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SolveBatchResponse) Reset() {
+	*x = SolveBatchResponse{}
+	mi := &file_sudoku_v1_sudoku_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SolveBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SolveBatchResponse) ProtoMessage() {}
+
+func (x *SolveBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sudoku_v1_sudoku_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SolveBatchResponse.ProtoReflect.Descriptor instead.
+func (*SolveBatchResponse) Descriptor() ([]byte, []int) {
+	return file_sudoku_v1_sudoku_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SolveBatchResponse) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *SolveBatchResponse) GetResponse() *SolveResponse {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+type GenerateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// difficulty is one of Easy, Medium, Hard, Expert or Evil, Easy when it is empty.
+	Difficulty string `protobuf:"bytes,1,opt,name=difficulty,proto3" json:"difficulty,omitempty"`
+	// symmetry is one of None, Rotational, Mirror or Diagonal, None when it is empty.
+	Symmetry string `protobuf:"bytes,2,opt,name=symmetry,proto3" json:"symmetry,omitempty"`
+	// seed makes the puzzle reproducible, a random seed is used when it is 0.
+	Seed          uint64 `protobuf:"varint,3,opt,name=seed,proto3" json:"seed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateRequest) Reset() {
+	*x = GenerateRequest{}
+	mi := &file_sudoku_v1_sudoku_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateRequest) ProtoMessage() {}
+
+func (x *GenerateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sudoku_v1_sudoku_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateRequest.ProtoReflect.Descriptor instead.
+func (*GenerateRequest) Descriptor() ([]byte, []int) {
+	return file_sudoku_v1_sudoku_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GenerateRequest) GetDifficulty() string {
+	if x != nil {
+		return x.Difficulty
+	}
+	return ""
+}
+
+func (x *GenerateRequest) GetSymmetry() string {
+	if x != nil {
+		return x.Symmetry
+	}
+	return ""
+}
+
+func (x *GenerateRequest) GetSeed() uint64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
+type GenerateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Puzzle        string                 `protobuf:"bytes,1,opt,name=puzzle,proto3" json:"puzzle,omitempty"`
+	Solution      string                 `protobuf:"bytes,2,opt,name=solution,proto3" json:"solution,omitempty"`
+	Difficulty    string                 `protobuf:"bytes,3,opt,name=difficulty,proto3" json:"difficulty,omitempty"`
+	Symmetry      string                 `protobuf:"bytes,4,opt,name=symmetry,proto3" json:"symmetry,omitempty"`
+	Seed          uint64                 `protobuf:"varint,5,opt,name=seed,proto3" json:"seed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateResponse) Reset() {
+	*x = GenerateResponse{}
+	mi := &file_sudoku_v1_sudoku_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateResponse) ProtoMessage() {}
+
+func (x *GenerateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sudoku_v1_sudoku_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateResponse.ProtoReflect.Descriptor instead.
+func (*GenerateResponse) Descriptor() ([]byte, []int) {
+	return file_sudoku_v1_sudoku_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GenerateResponse) GetPuzzle() string {
+	if x != nil {
+		return x.Puzzle
+	}
+	return ""
+}
+
+func (x *GenerateResponse) GetSolution() string {
+	if x != nil {
+		return x.Solution
+	}
+	return ""
+}
+
+func (x *GenerateResponse) GetDifficulty() string {
+	if x != nil {
+		return x.Difficulty
+	}
+	return ""
+}
+
+func (x *GenerateResponse) GetSymmetry() string {
+	if x != nil {
+		return x.Symmetry
+	}
+	return ""
+}
+
+func (x *GenerateResponse) GetSeed() uint64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
+var File_sudoku_v1_sudoku_proto protoreflect.FileDescriptor
+
+const file_sudoku_v1_sudoku_proto_rawDesc = "" +
+	"\n" +
+	"\x16sudoku/v1/sudoku.proto\x12\tsudoku.v1\x1a\x1egoogle/protobuf/duration.proto\"Q\n" +
+	"\fSolveOptions\x12\x1e\n" +
+	"\n" +
+	"strategies\x18\x01 \x03(\tR\n" +
+	"strategies\x12!\n" +
+	"\fno_backtrack\x18\x02 \x01(\bR\vnoBacktrack\"Y\n" +
+	"\fSolveRequest\x12\x16\n" +
+	"\x06puzzle\x18\x01 \x01(\tR\x06puzzle\x121\n" +
+	"\aoptions\x18\x02 \x01(\v2\x17.sudoku.v1.SolveOptionsR\aoptions\"\xf6\x01\n" +
+	"\tErrorInfo\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x12\n" +
+	"\x04unit\x18\x03 \x01(\tR\x04unit\x12\x1d\n" +
+	"\n" +
+	"unit_index\x18\x04 \x01(\x05R\tunitIndex\x12\x14\n" +
+	"\x05value\x18\x05 \x01(\x05R\x05value\x12\x14\n" +
+	"\x05cells\x18\x06 \x03(\tR\x05cells\x12\x1a\n" +
+	"\bstrategy\x18\a \x01(\tR\bstrategy\x12\x14\n" +
+	"\x05count\x18\b \x01(\x05R\x05count\x12\x12\n" +
+	"\x04line\x18\t \x01(\x05R\x04line\x12\x16\n" +
+	"\x06column\x18\n" +
+	" \x01(\x05R\x06column\"\x99\x03\n" +
+	"\rSolveResponse\x12\x18\n" +
+	"\ainitial\x18\x01 \x01(\tR\ainitial\x12\x1a\n" +
+	"\bsolution\x18\x02 \x01(\tR\bsolution\x12\x16\n" +
+	"\x06solved\x18\x03 \x01(\bR\x06solved\x12\x1e\n" +
+	"\n" +
+	"difficulty\x18\x04 \x01(\tR\n" +
+	"difficulty\x12'\n" +
+	"\x0fclue_difficulty\x18\x05 \x01(\tR\x0eclueDifficulty\x12\x14\n" +
+	"\x05score\x18\x06 \x01(\x01R\x05score\x12)\n" +
+	"\x10hardest_strategy\x18\a \x01(\tR\x0fhardestStrategy\x12\x16\n" +
+	"\x06givens\x18\b \x01(\x05R\x06givens\x12\x1f\n" +
+	"\vduration_ns\x18\t \x01(\x03R\n" +
+	"durationNs\x12+\n" +
+	"\x11backtracking_used\x18\n" +
+	" \x01(\bR\x10backtrackingUsed\x12\x1e\n" +
+	"\n" +
+	"strategies\x18\v \x03(\tR\n" +
+	"strategies\x12*\n" +
+	"\x05error\x18\f \x01(\v2\x14.sudoku.v1.ErrorInfoR\x05error\"%\n" +
+	"\vRateRequest\x12\x16\n" +
+	"\x06puzzle\x18\x01 \x01(\tR\x06puzzle\"\xb5\x02\n" +
+	"\fRateResponse\x12\x14\n" +
+	"\x05score\x18\x01 \x01(\x01R\x05score\x12\x1e\n" +
+	"\n" +
+	"difficulty\x18\x02 \x01(\tR\n" +
+	"difficulty\x12)\n" +
+	"\x10hardest_strategy\x18\x03 \x01(\tR\x0fhardestStrategy\x12T\n" +
+	"\x0fstrategy_counts\x18\x04 \x03(\v2+.sudoku.v1.RateResponse.StrategyCountsEntryR\x0estrategyCounts\x12+\n" +
+	"\x11backtracking_used\x18\x05 \x01(\bR\x10backtrackingUsed\x1aA\n" +
+	"\x13StrategyCountsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"X\n" +
+	"\vHintRequest\x12\x16\n" +
+	"\x06puzzle\x18\x01 \x01(\tR\x06puzzle\x121\n" +
+	"\aoptions\x18\x02 \x01(\v2\x17.sudoku.v1.SolveOptionsR\aoptions\"5\n" +
+	"\tPlacement\x12\x12\n" +
+	"\x04cell\x18\x01 \x01(\tR\x04cell\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value\"9\n" +
+	"\vElimination\x12\x12\n" +
+	"\x04cell\x18\x01 \x01(\tR\x04cell\x12\x16\n" +
+	"\x06digits\x18\x02 \x03(\x05R\x06digits\"<\n" +
+	"\fColorCluster\x12\x14\n" +
+	"\x05first\x18\x01 \x03(\tR\x05first\x12\x16\n" +
+	"\x06second\x18\x02 \x03(\tR\x06second\"\xd3\x02\n" +
+	"\fHintResponse\x12\x1a\n" +
+	"\bstrategy\x18\x01 \x01(\tR\bstrategy\x12\x18\n" +
+	"\apattern\x18\x02 \x03(\tR\apattern\x12\x16\n" +
+	"\x06digits\x18\x03 \x03(\x05R\x06digits\x124\n" +
+	"\n" +
+	"placements\x18\x04 \x03(\v2\x14.sudoku.v1.PlacementR\n" +
+	"placements\x12:\n" +
+	"\feliminations\x18\x05 \x03(\v2\x16.sudoku.v1.EliminationR\feliminations\x12\x18\n" +
+	"\atargets\x18\x06 \x03(\tR\atargets\x12 \n" +
+	"\vexplanation\x18\a \x01(\tR\vexplanation\x12\x12\n" +
+	"\x04fins\x18\b \x03(\tR\x04fins\x123\n" +
+	"\bclusters\x18\t \x03(\v2\x17.sudoku.v1.ColorClusterR\bclusters\"\xd4\x01\n" +
+	"\x11SolveBatchRequest\x12\x18\n" +
+	"\apuzzles\x18\x01 \x03(\tR\apuzzles\x121\n" +
+	"\aoptions\x18\x02 \x01(\v2\x17.sudoku.v1.SolveOptionsR\aoptions\x12\x18\n" +
+	"\aworkers\x18\x03 \x01(\x05R\aworkers\x12>\n" +
+	"\rboard_timeout\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\fboardTimeout\x12\x18\n" +
+	"\aordered\x18\x05 \x01(\bR\aordered\"`\n" +
+	"\x12SolveBatchResponse\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x124\n" +
+	"\bresponse\x18\x02 \x01(\v2\x18.sudoku.v1.SolveResponseR\bresponse\"a\n" +
+	"\x0fGenerateRequest\x12\x1e\n" +
+	"\n" +
+	"difficulty\x18\x01 \x01(\tR\n" +
+	"difficulty\x12\x1a\n" +
+	"\bsymmetry\x18\x02 \x01(\tR\bsymmetry\x12\x12\n" +
+	"\x04seed\x18\x03 \x01(\x04R\x04seed\"\x96\x01\n" +
+	"\x10GenerateResponse\x12\x16\n" +
+	"\x06puzzle\x18\x01 \x01(\tR\x06puzzle\x12\x1a\n" +
+	"\bsolution\x18\x02 \x01(\tR\bsolution\x12\x1e\n" +
+	"\n" +
+	"difficulty\x18\x03 \x01(\tR\n" +
+	"difficulty\x12\x1a\n" +
+	"\bsymmetry\x18\x04 \x01(\tR\bsymmetry\x12\x12\n" +
+	"\x04seed\x18\x05 \x01(\x04R\x04seed2\xcf\x02\n" +
+	"\rSudokuService\x12:\n" +
+	"\x05Solve\x12\x17.sudoku.v1.SolveRequest\x1a\x18.sudoku.v1.SolveResponse\x127\n" +
+	"\x04Rate\x12\x16.sudoku.v1.RateRequest\x1a\x17.sudoku.v1.RateResponse\x127\n" +
+	"\x04Hint\x12\x16.sudoku.v1.HintRequest\x1a\x17.sudoku.v1.HintResponse\x12K\n" +
+	"\n" +
+	"SolveBatch\x12\x1c.sudoku.v1.SolveBatchRequest\x1a\x1d.sudoku.v1.SolveBatchResponse0\x01\x12C\n" +
+	"\bGenerate\x12\x1a.sudoku.v1.GenerateRequest\x1a\x1b.sudoku.v1.GenerateResponseB=Z;github.com/chasankm/sudoku-solver/pkg/rpc/sudokupb;sudokupbb\x06proto3"
+
+var (
+	file_sudoku_v1_sudoku_proto_rawDescOnce sync.Once
+	file_sudoku_v1_sudoku_proto_rawDescData []byte
+)
+
+func file_sudoku_v1_sudoku_proto_rawDescGZIP() []byte {
+	file_sudoku_v1_sudoku_proto_rawDescOnce.Do(func() {
+		file_sudoku_v1_sudoku_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_sudoku_v1_sudoku_proto_rawDesc), len(file_sudoku_v1_sudoku_proto_rawDesc)))
+	})
+	return file_sudoku_v1_sudoku_proto_rawDescData
+}
+
+var file_sudoku_v1_sudoku_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_sudoku_v1_sudoku_proto_goTypes = []any{
+	(*SolveOptions)(nil),        // 0: sudoku.v1.SolveOptions
+	(*SolveRequest)(nil),        // 1: sudoku.v1.SolveRequest
+	(*ErrorInfo)(nil),           // 2: sudoku.v1.ErrorInfo
+	(*SolveResponse)(nil),       // 3: sudoku.v1.SolveResponse
+	(*RateRequest)(nil),         // 4: sudoku.v1.RateRequest
+	(*RateResponse)(nil),        // 5: sudoku.v1.RateResponse
+	(*HintRequest)(nil),         // 6: sudoku.v1.HintRequest
+	(*Placement)(nil),           // 7: sudoku.v1.Placement
+	(*Elimination)(nil),         // 8: sudoku.v1.Elimination
+	(*ColorCluster)(nil),        // 9: sudoku.v1.ColorCluster
+	(*HintResponse)(nil),        // 10: sudoku.v1.HintResponse
+	(*SolveBatchRequest)(nil),   // 11: sudoku.v1.SolveBatchRequest
+	(*SolveBatchResponse)(nil),  // 12: sudoku.v1.SolveBatchResponse
+	(*GenerateRequest)(nil),     // 13: sudoku.v1.GenerateRequest
+	(*GenerateResponse)(nil),    // 14: sudoku.v1.GenerateResponse
+	nil,                         // 15: sudoku.v1.RateResponse.StrategyCountsEntry
+	(*durationpb.Duration)(nil), // 16: google.protobuf.Duration
+}
+var file_sudoku_v1_sudoku_proto_depIdxs = []int32{
+	0,  // 0: sudoku.v1.SolveRequest.options:type_name -> sudoku.v1.SolveOptions
+	2,  // 1: sudoku.v1.SolveResponse.error:type_name -> sudoku.v1.ErrorInfo
+	15, // 2: sudoku.v1.RateResponse.strategy_counts:type_name -> sudoku.v1.RateResponse.StrategyCountsEntry
+	0,  // 3: sudoku.v1.HintRequest.options:type_name -> sudoku.v1.SolveOptions
+	7,  // 4: sudoku.v1.HintResponse.placements:type_name -> sudoku.v1.Placement
+	8,  // 5: sudoku.v1.HintResponse.eliminations:type_name -> sudoku.v1.Elimination
+	9,  // 6: sudoku.v1.HintResponse.clusters:type_name -> sudoku.v1.ColorCluster
+	0,  // 7: sudoku.v1.SolveBatchRequest.options:type_name -> sudoku.v1.SolveOptions
+	16, // 8: sudoku.v1.SolveBatchRequest.board_timeout:type_name -> google.protobuf.Duration
+	3,  // 9: sudoku.v1.SolveBatchResponse.response:type_name -> sudoku.v1.SolveResponse
+	1,  // 10: sudoku.v1.SudokuService.Solve:input_type -> sudoku.v1.SolveRequest
+	4,  // 11: sudoku.v1.SudokuService.Rate:input_type -> sudoku.v1.RateRequest
+	6,  // 12: sudoku.v1.SudokuService.Hint:input_type -> sudoku.v1.HintRequest
+	11, // 13: sudoku.v1.SudokuService.SolveBatch:input_type -> sudoku.v1.SolveBatchRequest
+	13, // 14: sudoku.v1.SudokuService.Generate:input_type -> sudoku.v1.GenerateRequest
+	3,  // 15: sudoku.v1.SudokuService.Solve:output_type -> sudoku.v1.SolveResponse
+	5,  // 16: sudoku.v1.SudokuService.Rate:output_type -> sudoku.v1.RateResponse
+	10, // 17: sudoku.v1.SudokuService.Hint:output_type -> sudoku.v1.HintResponse
+	12, // 18: sudoku.v1.SudokuService.SolveBatch:output_type -> sudoku.v1.SolveBatchResponse
+	14, // 19: sudoku.v1.SudokuService.Generate:output_type -> sudoku.v1.GenerateResponse
+	15, // [15:20] is the sub-list for method output_type
+	10, // [10:15] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_sudoku_v1_sudoku_proto_init() }
+func file_sudoku_v1_sudoku_proto_init() {
+	if File_sudoku_v1_sudoku_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_sudoku_v1_sudoku_proto_rawDesc), len(file_sudoku_v1_sudoku_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   16,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_sudoku_v1_sudoku_proto_goTypes,
+		DependencyIndexes: file_sudoku_v1_sudoku_proto_depIdxs,
+		MessageInfos:      file_sudoku_v1_sudoku_proto_msgTypes,
+	}.Build()
+	File_sudoku_v1_sudoku_proto = out.File
+	file_sudoku_v1_sudoku_proto_goTypes = nil
+	file_sudoku_v1_sudoku_proto_depIdxs = nil
+}
@@ -0,0 +1,295 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: sudoku/v1/sudoku.proto
+
+package sudokupb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	SudokuService_Solve_FullMethodName      = "/sudoku.v1.SudokuService/Solve"
+	SudokuService_Rate_FullMethodName       = "/sudoku.v1.SudokuService/Rate"
+	SudokuService_Hint_FullMethodName       = "/sudoku.v1.SudokuService/Hint"
+	SudokuService_SolveBatch_FullMethodName = "/sudoku.v1.SudokuService/SolveBatch"
+	SudokuService_Generate_FullMethodName   = "/sudoku.v1.SudokuService/Generate"
+)
+
+// SudokuServiceClient is the client API for SudokuService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// SudokuService solves, rates and generates puzzles. The puzzles are strings in any format read by
+// solver.ReadPuzzles, e.g. the 81 character line format with '.' or '0' for the empty cells.
+type SudokuServiceClient interface {
+	// Solve solves a single puzzle. Invalid puzzles fail with INVALID_ARGUMENT, a puzzle which can't be solved returns
+	// a response with its error.
+	Solve(ctx context.Context, in *SolveRequest, opts ...grpc.CallOption) (*SolveResponse, error)
+	// Rate rates a puzzle by the strategies it needs.
+	Rate(ctx context.Context, in *RateRequest, opts ...grpc.CallOption) (*RateResponse, error)
+	// Hint returns the easiest deduction available on a puzzle, FAILED_PRECONDITION if there is none.
+	Hint(ctx context.Context, in *HintRequest, opts ...grpc.CallOption) (*HintResponse, error)
+	// SolveBatch solves the puzzles with a pool of workers and streams a response as each puzzle finishes.
+	SolveBatch(ctx context.Context, in *SolveBatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SolveBatchResponse], error)
+	// Generate generates a puzzle with a unique solution.
+	Generate(ctx context.Context, in *GenerateRequest, opts ...grpc.CallOption) (*GenerateResponse, error)
+}
+
+type sudokuServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSudokuServiceClient(cc grpc.ClientConnInterface) SudokuServiceClient {
+	return &sudokuServiceClient{cc}
+}
+
+func (c *sudokuServiceClient) Solve(ctx context.Context, in *SolveRequest, opts ...grpc.CallOption) (*SolveResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SolveResponse)
+	err := c.cc.Invoke(ctx, SudokuService_Solve_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sudokuServiceClient) Rate(ctx context.Context, in *RateRequest, opts ...grpc.CallOption) (*RateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RateResponse)
+	err := c.cc.Invoke(ctx, SudokuService_Rate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sudokuServiceClient) Hint(ctx context.Context, in *HintRequest, opts ...grpc.CallOption) (*HintResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HintResponse)
+	err := c.cc.Invoke(ctx, SudokuService_Hint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sudokuServiceClient) SolveBatch(ctx context.Context, in *SolveBatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SolveBatchResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SudokuService_ServiceDesc.Streams[0], SudokuService_SolveBatch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SolveBatchRequest, SolveBatchResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SudokuService_SolveBatchClient = grpc.ServerStreamingClient[SolveBatchResponse]
+
+func (c *sudokuServiceClient) Generate(ctx context.Context, in *GenerateRequest, opts ...grpc.CallOption) (*GenerateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GenerateResponse)
+	err := c.cc.Invoke(ctx, SudokuService_Generate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SudokuServiceServer is the server API for SudokuService service.
+// All implementations must embed UnimplementedSudokuServiceServer
+// for forward compatibility.
+//
+// SudokuService solves, rates and generates puzzles. The puzzles are strings in any format read by
+// solver.ReadPuzzles, e.g. the 81 character line format with '.' or '0' for the empty cells.
+type SudokuServiceServer interface {
+	// Solve solves a single puzzle. Invalid puzzles fail with INVALID_ARGUMENT, a puzzle which can't be solved returns
+	// a response with its error.
+	Solve(context.Context, *SolveRequest) (*SolveResponse, error)
+	// Rate rates a puzzle by the strategies it needs.
+	Rate(context.Context, *RateRequest) (*RateResponse, error)
+	// Hint returns the easiest deduction available on a puzzle, FAILED_PRECONDITION if there is none.
+	Hint(context.Context, *HintRequest) (*HintResponse, error)
+	// SolveBatch solves the puzzles with a pool of workers and streams a response as each puzzle finishes.
+	SolveBatch(*SolveBatchRequest, grpc.ServerStreamingServer[SolveBatchResponse]) error
+	// Generate generates a puzzle with a unique solution.
+	Generate(context.Context, *GenerateRequest) (*GenerateResponse, error)
+	mustEmbedUnimplementedSudokuServiceServer()
+}
+
+// UnimplementedSudokuServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSudokuServiceServer struct{}
+
+func (UnimplementedSudokuServiceServer) Solve(context.Context, *SolveRequest) (*SolveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Solve not implemented")
+}
+func (UnimplementedSudokuServiceServer) Rate(context.Context, *RateRequest) (*RateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Rate not implemented")
+}
+func (UnimplementedSudokuServiceServer) Hint(context.Context, *HintRequest) (*HintResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Hint not implemented")
+}
+func (UnimplementedSudokuServiceServer) SolveBatch(*SolveBatchRequest, grpc.ServerStreamingServer[SolveBatchResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method SolveBatch not implemented")
+}
+func (UnimplementedSudokuServiceServer) Generate(context.Context, *GenerateRequest) (*GenerateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Generate not implemented")
+}
+func (UnimplementedSudokuServiceServer) mustEmbedUnimplementedSudokuServiceServer() {}
+func (UnimplementedSudokuServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeSudokuServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SudokuServiceServer will
+// result in compilation errors.
+type UnsafeSudokuServiceServer interface {
+	mustEmbedUnimplementedSudokuServiceServer()
+}
+
+func RegisterSudokuServiceServer(s grpc.ServiceRegistrar, srv SudokuServiceServer) {
+	// If the following call pancis, it indicates UnimplementedSudokuServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SudokuService_ServiceDesc, srv)
+}
+
+func _SudokuService_Solve_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SolveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SudokuServiceServer).Solve(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SudokuService_Solve_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SudokuServiceServer).Solve(ctx, req.(*SolveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SudokuService_Rate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SudokuServiceServer).Rate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SudokuService_Rate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SudokuServiceServer).Rate(ctx, req.(*RateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SudokuService_Hint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HintRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SudokuServiceServer).Hint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SudokuService_Hint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SudokuServiceServer).Hint(ctx, req.(*HintRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SudokuService_SolveBatch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SolveBatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SudokuServiceServer).SolveBatch(m, &grpc.GenericServerStream[SolveBatchRequest, SolveBatchResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SudokuService_SolveBatchServer = grpc.ServerStreamingServer[SolveBatchResponse]
+
+func _SudokuService_Generate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SudokuServiceServer).Generate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SudokuService_Generate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SudokuServiceServer).Generate(ctx, req.(*GenerateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SudokuService_ServiceDesc is the grpc.ServiceDesc for SudokuService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SudokuService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "sudoku.v1.SudokuService",
+	HandlerType: (*SudokuServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Solve",
+			Handler:    _SudokuService_Solve_Handler,
+		},
+		{
+			MethodName: "Rate",
+			Handler:    _SudokuService_Rate_Handler,
+		},
+		{
+			MethodName: "Hint",
+			Handler:    _SudokuService_Hint_Handler,
+		},
+		{
+			MethodName: "Generate",
+			Handler:    _SudokuService_Generate_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SolveBatch",
+			Handler:       _SudokuService_SolveBatch_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "sudoku/v1/sudoku.proto",
+}
@@ -0,0 +1,542 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/chasankm/sudoku-solver/pkg/generator"
+	"github.com/chasankm/sudoku-solver/pkg/solver"
+)
+
+const (
+	defaultTimeout      = 10 * time.Second
+	defaultMaxBodyBytes = 1 << 20
+	defaultCountLimit   = 2
+	maxCountLimit       = 1000
+)
+
+const (
+	// BadRequestErrorType is returned for the request bodies and parameters which can't be decoded
+	BadRequestErrorType solver.ErrorType = "bad_request"
+	// OverloadedErrorType is returned when all the solve slots are busy
+	OverloadedErrorType solver.ErrorType = "overloaded"
+	// NoHintErrorType is returned when the board is solved or no deduction is available
+	NoHintErrorType solver.ErrorType = "no_hint"
+)
+
+// errorStatuses maps the error types to the HTTP statuses, the other types are internal errors
+var errorStatuses = map[solver.ErrorType]int{
+	BadRequestErrorType:               http.StatusBadRequest,
+	solver.ParseErrorType:             http.StatusBadRequest,
+	solver.ConflictErrorType:          http.StatusUnprocessableEntity,
+	solver.TooFewGivensErrorType:      http.StatusUnprocessableEntity,
+	solver.InvalidValueErrorType:      http.StatusUnprocessableEntity,
+	solver.EmptyCandidatesErrorType:   http.StatusUnprocessableEntity,
+	solver.NoSolutionErrorType:        http.StatusUnprocessableEntity,
+	solver.MultipleSolutionsErrorType: http.StatusUnprocessableEntity,
+	solver.StalledErrorType:           http.StatusUnprocessableEntity,
+	NoHintErrorType:                   http.StatusUnprocessableEntity,
+	solver.CanceledErrorType:          http.StatusGatewayTimeout,
+	OverloadedErrorType:               http.StatusServiceUnavailable,
+}
+
+// Config is the configuration of the server, the zero values are replaced by the defaults
+type Config struct {
+	// Timeout limits the work of each request, 10 seconds by default
+	Timeout time.Duration
+	// MaxConcurrent is the number of requests solved at the same time, runtime.NumCPU() by default. The requests
+	// beyond the limit are rejected with 503
+	MaxConcurrent int
+	// MaxBodyBytes limits the size of the request bodies, 1 MiB by default
+	MaxBodyBytes int64
+	// Solver solves, rates and counts the boards of the POST endpoints, the default pipeline is used when it is nil
+	Solver *solver.Solver
+}
+
+// Server is the HTTP handler of the solver
+type Server struct {
+	config Config
+	slots  chan struct{}
+	ready  atomic.Bool
+	mux    *http.ServeMux
+}
+
+// New returns a ready server with the given configuration
+func New(config Config) (*Server, error) {
+	if config.Timeout <= 0 {
+		config.Timeout = defaultTimeout
+	}
+	if config.MaxConcurrent <= 0 {
+		config.MaxConcurrent = runtime.NumCPU()
+	}
+	if config.MaxBodyBytes <= 0 {
+		config.MaxBodyBytes = defaultMaxBodyBytes
+	}
+	if config.Solver == nil {
+		s, err := solver.New()
+		if err != nil {
+			return nil, err
+		}
+		config.Solver = s
+	}
+
+	s := &Server{
+		config: config,
+		slots:  make(chan struct{}, config.MaxConcurrent),
+		mux:    http.NewServeMux(),
+	}
+	s.ready.Store(true)
+	s.mux.HandleFunc("POST /solve", s.handle(s.solve))
+	s.mux.HandleFunc("POST /hint", s.handle(s.hint))
+	s.mux.HandleFunc("POST /rate", s.handle(s.rate))
+	s.mux.HandleFunc("POST /validate", s.handle(s.validate))
+	s.mux.HandleFunc("POST /count-solutions", s.handle(s.countSolutions))
+	s.mux.HandleFunc("GET /generate", s.handle(s.generate))
+	s.mux.HandleFunc("GET /healthz", s.health)
+	s.mux.HandleFunc("GET /readyz", s.readiness)
+	return s, nil
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// SetReady changes the readiness reported by /readyz, e.g. it is set to false while the server shuts down
+func (s *Server) SetReady(ready bool) {
+	s.ready.Store(ready)
+}
+
+// StatusCode returns the HTTP status of the given error
+func StatusCode(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	if status, ok := errorStatuses[typeOf(err)]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// requestError is an error with an error type of the server
+type requestError struct {
+	errorType solver.ErrorType
+	err       error
+}
+
+func (e *requestError) Error() string {
+	return e.err.Error()
+}
+
+func (e *requestError) Unwrap() error {
+	return e.err
+}
+
+func typeOf(err error) solver.ErrorType {
+	var reqErr *requestError
+	if errors.As(err, &reqErr) {
+		return reqErr.errorType
+	}
+	return solver.NewErrorInfo(err).Type
+}
+
+func errorInfo(err error) *solver.ErrorInfo {
+	info := solver.NewErrorInfo(err)
+	var reqErr *requestError
+	if errors.As(err, &reqErr) {
+		info.Type = reqErr.errorType
+	}
+	return info
+}
+
+func badRequest(format string, args ...any) error {
+	return &requestError{errorType: BadRequestErrorType, err: fmt.Errorf(format, args...)}
+}
+
+// errorResponse is the body of the failed requests
+type errorResponse struct {
+	Error *solver.ErrorInfo `json:"error"`
+}
+
+// request keeps the parts of the HTTP request used by the handlers, the body is read before the handler runs so it
+// isn't read after a timed out request returns
+type request struct {
+	body  []byte
+	query url.Values
+}
+
+// handlerFunc does the work of an endpoint and returns the response body or the error, the status is derived from
+// the error
+type handlerFunc func(ctx context.Context, r *request) (any, error)
+
+// handle runs the handler in a solve slot with the request timeout. The slot is kept until the handler returns even
+// if the response is already written for the timeout, so the limit also covers the work which can't be cancelled
+func (s *Server) handle(handler handlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case s.slots <- struct{}{}:
+		default:
+			w.Header().Set("Retry-After", "1")
+			writeError(w, &requestError{errorType: OverloadedErrorType, err: errors.New("all solve slots are busy")})
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, s.config.MaxBodyBytes))
+		if err != nil {
+			<-s.slots
+			writeError(w, badRequest("invalid request body: %s", err.Error()))
+			return
+		}
+		req := &request{body: body, query: r.URL.Query()}
+		ctx, cancel := context.WithTimeout(r.Context(), s.config.Timeout)
+		defer cancel()
+
+		type result struct {
+			body any
+			err  error
+		}
+		done := make(chan result, 1)
+		go func() {
+			defer func() { <-s.slots }()
+			response, err := handler(ctx, req)
+			done <- result{body: response, err: err}
+		}()
+
+		select {
+		case res := <-done:
+			var failure *solveFailure
+			if errors.As(res.err, &failure) {
+				writeJSON(w, StatusCode(res.err), failure.response)
+				return
+			}
+			if res.err != nil {
+				writeError(w, res.err)
+				return
+			}
+			writeJSON(w, http.StatusOK, res.body)
+		case <-ctx.Done():
+			writeError(w, &solver.CanceledError{Err: ctx.Err()})
+		}
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	writeJSON(w, StatusCode(err), errorResponse{Error: errorInfo(err)})
+}
+
+// puzzleRequest is the body of the POST endpoints, the puzzle can be in any format read by solver.ReadPuzzles
+type puzzleRequest struct {
+	Puzzle      string `json:"puzzle"`
+	NoBacktrack bool   `json:"no_backtrack,omitempty"`
+	Unique      bool   `json:"unique,omitempty"`
+	Limit       int    `json:"limit,omitempty"`
+}
+
+// decodePuzzle decodes the request body and returns the request with its puzzle
+func decodePuzzle(r *request) (*puzzleRequest, *solver.Puzzle, error) {
+	decoded := &puzzleRequest{}
+	decoder := json.NewDecoder(bytes.NewReader(r.body))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(decoded); err != nil {
+		return nil, nil, badRequest("invalid request body: %s", err.Error())
+	}
+	if strings.TrimSpace(decoded.Puzzle) == "" {
+		return nil, nil, badRequest("puzzle is required")
+	}
+	puzzles, err := solver.ReadPuzzles(strings.NewReader(decoded.Puzzle), solver.AutoFormat)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(puzzles) != 1 {
+		return nil, nil, badRequest("request should contain exactly one puzzle, found %d", len(puzzles))
+	}
+	return decoded, puzzles[0], nil
+}
+
+// decodeBoard decodes the request body and returns the request with the board of its puzzle
+func decodeBoard(r *request) (*puzzleRequest, *solver.Board, error) {
+	decoded, puzzle, err := decodePuzzle(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	board, err := puzzle.Board()
+	if err != nil {
+		return nil, nil, err
+	}
+	return decoded, board, nil
+}
+
+func (s *Server) solve(ctx context.Context, r *request) (any, error) {
+	decoded, board, err := decodeBoard(r)
+	if err != nil {
+		return nil, err
+	}
+	if decoded.Unique {
+		count, err := s.config.Solver.CountSolutionsContext(ctx, board, 2)
+		if err != nil {
+			return nil, err
+		}
+		if count != 1 {
+			if count == 0 {
+				return nil, &solver.NoSolutionError{}
+			}
+			return nil, &solver.MultipleSolutionsError{Count: count}
+		}
+	}
+	pipeline := s.config.Solver
+	if decoded.NoBacktrack {
+		pipeline, err = s.config.Solver.With(solver.WithoutBacktracking())
+		if err != nil {
+			return nil, err
+		}
+	}
+	response := pipeline.SolveContext(ctx, board)
+	if response.Error != nil {
+		return nil, &solveFailure{response: response}
+	}
+	return response, nil
+}
+
+// solveFailure keeps the response of a failed solve so the failure is written with the solve record and the status
+// of its error
+type solveFailure struct {
+	response *solver.SolveResponse
+}
+
+func (e *solveFailure) Error() string {
+	return e.response.Error.Error()
+}
+
+func (e *solveFailure) Unwrap() error {
+	return e.response.Error
+}
+
+// hintResponse is the body of /hint
+type hintResponse struct {
+	Strategy     string              `json:"strategy"`
+	Pattern      []string            `json:"pattern"`
+	Digits       []int               `json:"digits"`
+	Placements   []placementResponse `json:"placements"`
+	Eliminations []elimination       `json:"eliminations"`
+	Targets      []string            `json:"targets"`
+	Explanation  string              `json:"explanation"`
+	Fins         []string            `json:"fins,omitempty"`
+	Clusters     []clusterResponse   `json:"clusters,omitempty"`
+}
+
+// clusterResponse is a color cluster of a coloring hint, either every cell of one color holds the digit or every cell
+// of the other one does
+type clusterResponse struct {
+	Colors [2][]string `json:"colors"`
+}
+
+type placementResponse struct {
+	Cell  string `json:"cell"`
+	Value int    `json:"value"`
+}
+
+type elimination struct {
+	Cell   string `json:"cell"`
+	Digits []int  `json:"digits"`
+}
+
+func (s *Server) hint(_ context.Context, r *request) (any, error) {
+	_, board, err := decodeBoard(r)
+	if err != nil {
+		return nil, err
+	}
+	hint, err := s.config.Solver.Hint(board)
+	if errors.Is(err, solver.ErrAlreadySolved) || errors.Is(err, solver.ErrNoDeduction) {
+		return nil, &requestError{errorType: NoHintErrorType, err: err}
+	}
+	if err != nil {
+		return nil, err
+	}
+	response := &hintResponse{
+		Strategy:     hint.Strategy.String(),
+		Pattern:      solver.RefStrings(hint.Pattern),
+		Digits:       hint.Digits.ToArray(),
+		Placements:   make([]placementResponse, 0, len(hint.Placements)),
+		Eliminations: make([]elimination, 0, len(hint.Eliminations)),
+		Targets:      solver.RefStrings(hint.Targets),
+		Explanation:  hint.Explanation,
+	}
+	if len(hint.Fins) > 0 {
+		response.Fins = solver.RefStrings(hint.Fins)
+	}
+	for _, cluster := range hint.Clusters {
+		response.Clusters = append(response.Clusters, clusterResponse{Colors: [2][]string{solver.RefStrings(cluster.Colors[0]), solver.RefStrings(cluster.Colors[1])}})
+	}
+	for _, placement := range hint.Placements {
+		response.Placements = append(response.Placements, placementResponse{Cell: placement.Cell.String(), Value: int(placement.Value)})
+	}
+	for _, removed := range hint.Eliminations {
+		response.Eliminations = append(response.Eliminations, elimination{Cell: removed.Cell.String(), Digits: removed.Marks.ToArray()})
+	}
+	return response, nil
+}
+
+// rateResponse is the body of /rate
+type rateResponse struct {
+	Score            float64        `json:"score"`
+	Difficulty       string         `json:"difficulty"`
+	HardestStrategy  string         `json:"hardest_strategy"`
+	StrategyCounts   map[string]int `json:"strategy_counts"`
+	BackTrackingUsed bool           `json:"backtracking_used"`
+}
+
+func (s *Server) rate(ctx context.Context, r *request) (any, error) {
+	_, board, err := decodeBoard(r)
+	if err != nil {
+		return nil, err
+	}
+	rating, err := s.config.Solver.RateContext(ctx, board)
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[string]int, len(rating.StrategyCounts))
+	for strategy, count := range rating.StrategyCounts {
+		counts[strategy.String()] = count
+	}
+	return &rateResponse{
+		Score:            rating.Score,
+		Difficulty:       solver.Levels[rating.Difficulty],
+		HardestStrategy:  rating.HardestStrategy.String(),
+		StrategyCounts:   counts,
+		BackTrackingUsed: rating.BackTrackingUsed,
+	}, nil
+}
+
+// validateResponse is the body of /validate, an invalid puzzle is a successful validation with the error
+type validateResponse struct {
+	Valid     bool              `json:"valid"`
+	Unique    bool              `json:"unique"`
+	Solutions int               `json:"solutions"`
+	Error     *solver.ErrorInfo `json:"error,omitempty"`
+}
+
+func (s *Server) validate(ctx context.Context, r *request) (any, error) {
+	_, puzzle, err := decodePuzzle(r)
+	if err != nil {
+		return nil, err
+	}
+	response := &validateResponse{}
+	board, err := puzzle.Board()
+	if err == nil {
+		response.Solutions, err = s.config.Solver.CountSolutionsContext(ctx, board, 2)
+		if err != nil {
+			return nil, err
+		}
+		response.Valid = true
+		switch response.Solutions {
+		case 0:
+			err = &solver.NoSolutionError{}
+		case 1:
+			response.Unique = true
+		default:
+			err = &solver.MultipleSolutionsError{Count: response.Solutions}
+		}
+	}
+	response.Error = solver.NewErrorInfo(err)
+	return response, nil
+}
+
+// countResponse is the body of /count-solutions
+type countResponse struct {
+	Solutions    int  `json:"solutions"`
+	Limit        int  `json:"limit"`
+	LimitReached bool `json:"limit_reached"`
+}
+
+func (s *Server) countSolutions(ctx context.Context, r *request) (any, error) {
+	decoded, board, err := decodeBoard(r)
+	if err != nil {
+		return nil, err
+	}
+	limit := decoded.Limit
+	if limit == 0 {
+		limit = defaultCountLimit
+	}
+	if limit < 1 || limit > maxCountLimit {
+		return nil, badRequest("limit should be between 1 and %d", maxCountLimit)
+	}
+	solutions, err := s.config.Solver.CountSolutionsContext(ctx, board, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &countResponse{Solutions: solutions, Limit: limit, LimitReached: solutions >= limit}, nil
+}
+
+// generateResponse is the body of /generate with the puzzle and the solution in the 81 character line format
+type generateResponse struct {
+	Puzzle     string `json:"puzzle"`
+	Solution   string `json:"solution"`
+	Difficulty string `json:"difficulty"`
+	Symmetry   string `json:"symmetry"`
+	Seed       uint64 `json:"seed"`
+}
+
+func (s *Server) generate(_ context.Context, r *request) (any, error) {
+	query := r.query
+	options := generator.Options{Difficulty: solver.Easy, Seed: uint64(time.Now().UnixNano())}
+	if name := query.Get("difficulty"); name != "" {
+		difficulty, err := solver.ParseDifficulty(name)
+		if err != nil {
+			return nil, &requestError{errorType: BadRequestErrorType, err: err}
+		}
+		options.Difficulty = difficulty
+	}
+	if name := query.Get("symmetry"); name != "" {
+		symmetry, err := generator.ParseSymmetry(name)
+		if err != nil {
+			return nil, &requestError{errorType: BadRequestErrorType, err: err}
+		}
+		options.Symmetry = symmetry
+	}
+	if value := query.Get("seed"); value != "" {
+		seed, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return nil, badRequest("invalid seed: %q", value)
+		}
+		options.Seed = seed
+	}
+	puzzle, err := generator.Generate(options)
+	if err != nil {
+		return nil, err
+	}
+	return &generateResponse{
+		Puzzle:     solver.GridString(puzzle.Givens),
+		Solution:   solver.GridString(puzzle.Solution),
+		Difficulty: solver.Levels[puzzle.Difficulty],
+		Symmetry:   puzzle.Symmetry.String(),
+		Seed:       puzzle.Seed,
+	}, nil
+}
+
+func (s *Server) health(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+func (s *Server) readiness(w http.ResponseWriter, _ *http.Request) {
+	if !s.ready.Load() {
+		writeJSON(w, http.StatusServiceUnavailable, map[string]string{"status": "not ready"})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ready"})
+}
@@ -0,0 +1,216 @@
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/chasankm/sudoku-solver/pkg/solver"
+)
+
+const (
+	easyBoard      = "003020600900305001001806400008102900700000008006708200002609500800203009005010300"
+	ambiguousBoard = "48392165796734582125187649354813297672956413813679824537268951481425..6969541..82"
+	conflictBoard  = "603020600900305001001806400008102900700000008006708200002609500800203009005010300"
+)
+
+func newTestServer(t *testing.T, config Config) (*Server, *httptest.Server) {
+	t.Helper()
+	handler, err := New(config)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	ts := httptest.NewServer(handler)
+	t.Cleanup(ts.Close)
+	return handler, ts
+}
+
+func do(t *testing.T, ts *httptest.Server, method string, path string, body string, out any) int {
+	t.Helper()
+	request, err := http.NewRequest(method, ts.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+	response, err := ts.Client().Do(request)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if out != nil {
+		if err := json.Unmarshal(data, out); err != nil {
+			t.Fatalf("Unmarshal(%s) error = %v", data, err)
+		}
+	}
+	return response.StatusCode
+}
+
+func TestSolveEndpointReturnsRecordsAndMapsErrors(t *testing.T) {
+	_, ts := newTestServer(t, Config{})
+
+	var record solver.SolveRecord
+	if status := do(t, ts, http.MethodPost, "/solve", `{"puzzle":"`+easyBoard+`"}`, &record); status != http.StatusOK {
+		t.Fatalf("POST /solve status = %d, want 200", status)
+	}
+	if !record.Solved || len(record.Solution) != 81 || strings.Contains(record.Solution, ".") {
+		t.Fatalf("POST /solve record = %+v", record)
+	}
+
+	tests := []struct {
+		name      string
+		body      string
+		status    int
+		errorType solver.ErrorType
+	}{
+		{name: "invalid json", body: `{"puzzle":`, status: http.StatusBadRequest, errorType: BadRequestErrorType},
+		{name: "unknown field", body: `{"board":"` + easyBoard + `"}`, status: http.StatusBadRequest, errorType: BadRequestErrorType},
+		{name: "invalid puzzle", body: `{"puzzle":"12x"}`, status: http.StatusBadRequest, errorType: solver.ParseErrorType},
+		{name: "conflict", body: `{"puzzle":"` + conflictBoard + `"}`, status: http.StatusUnprocessableEntity, errorType: solver.ConflictErrorType},
+		{name: "not unique", body: `{"puzzle":"` + ambiguousBoard + `","unique":true}`, status: http.StatusUnprocessableEntity, errorType: solver.MultipleSolutionsErrorType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var response errorResponse
+			if status := do(t, ts, http.MethodPost, "/solve", tt.body, &response); status != tt.status {
+				t.Fatalf("POST /solve status = %d, want %d", status, tt.status)
+			}
+			if response.Error == nil || response.Error.Type != tt.errorType {
+				t.Fatalf("POST /solve error = %+v, want type %s", response.Error, tt.errorType)
+			}
+		})
+	}
+}
+
+// probeStrategy is a custom strategy finding nothing, it records that the pipeline ran it
+type probeStrategy struct {
+	applied *atomic.Bool
+}
+
+func (s probeStrategy) Name() solver.StrategyName {
+	return "Probe"
+}
+
+func (s probeStrategy) Apply(*solver.Board) (bool, error) {
+	s.applied.Store(true)
+	return false, nil
+}
+
+func TestSolveWithoutBacktrackingKeepsConfiguredSolver(t *testing.T) {
+	probe := probeStrategy{applied: &atomic.Bool{}}
+	configured, err := solver.New(solver.WithStrategies(solver.HiddenSingleStrategy), solver.WithStrategy(probe), solver.WithBackend(solver.DLXBackend))
+	if err != nil {
+		t.Fatalf("solver.New() error = %v", err)
+	}
+	_, ts := newTestServer(t, Config{Solver: configured})
+
+	var response errorResponse
+	puzzle := "4.....8.5.3..........7......2.....6.....8.4......1.......6.3.7.5..2.....1.4......"
+	if status := do(t, ts, http.MethodPost, "/solve", `{"puzzle":"`+puzzle+`","no_backtrack":true}`, &response); status != http.StatusUnprocessableEntity {
+		t.Fatalf("POST /solve status = %d, want 422", status)
+	}
+	if response.Error == nil || response.Error.Type != solver.StalledErrorType || !probe.applied.Load() {
+		t.Fatalf("POST /solve error = %+v, probe applied = %t, want a stall after the custom strategy ran", response.Error, probe.applied.Load())
+	}
+	if configured.Backend() != solver.DLXBackend || len(configured.Strategies()) != 2 {
+		t.Fatal("POST /solve with no_backtrack should leave the configured solver as it is")
+	}
+}
+
+func TestHintEndpointReturnsColorClusters(t *testing.T) {
+	coloring, err := solver.New(solver.WithStrategies(solver.ColoringStrategy))
+	if err != nil {
+		t.Fatalf("solver.New() error = %v", err)
+	}
+	_, ts := newTestServer(t, Config{Solver: coloring})
+
+	var hint hintResponse
+	puzzle := "4.....8.5.3..........7......2.....6.....8.4......1.......6.3.7.5..2.....1.4......"
+	if status := do(t, ts, http.MethodPost, "/hint", `{"puzzle":"`+puzzle+`"}`, &hint); status != http.StatusOK || hint.Strategy != solver.ColoringStrategy.String() {
+		t.Fatalf("POST /hint status = %d, hint = %+v", status, hint)
+	}
+	if len(hint.Clusters) != 1 || len(hint.Clusters[0].Colors[0]) == 0 || len(hint.Clusters[0].Colors[1]) == 0 {
+		t.Fatalf("POST /hint clusters = %+v, want a cluster with both colors", hint.Clusters)
+	}
+}
+
+func TestPuzzleEndpoints(t *testing.T) {
+	_, ts := newTestServer(t, Config{})
+
+	var hint hintResponse
+	if status := do(t, ts, http.MethodPost, "/hint", `{"puzzle":"`+easyBoard+`"}`, &hint); status != http.StatusOK || hint.Strategy == "" || hint.Explanation == "" {
+		t.Fatalf("POST /hint status = %d, hint = %+v", status, hint)
+	}
+
+	var rating rateResponse
+	if status := do(t, ts, http.MethodPost, "/rate", `{"puzzle":"`+easyBoard+`"}`, &rating); status != http.StatusOK || rating.Difficulty == "" || rating.Score == 0 {
+		t.Fatalf("POST /rate status = %d, rating = %+v", status, rating)
+	}
+
+	var validation validateResponse
+	if status := do(t, ts, http.MethodPost, "/validate", `{"puzzle":"`+ambiguousBoard+`"}`, &validation); status != http.StatusOK {
+		t.Fatalf("POST /validate status = %d, want 200", status)
+	}
+	if !validation.Valid || validation.Unique || validation.Error == nil || validation.Error.Type != solver.MultipleSolutionsErrorType {
+		t.Fatalf("POST /validate = %+v, want a valid board with multiple solutions", validation)
+	}
+
+	var count countResponse
+	if status := do(t, ts, http.MethodPost, "/count-solutions", `{"puzzle":"`+ambiguousBoard+`","limit":10}`, &count); status != http.StatusOK || count.Solutions != 2 || count.LimitReached {
+		t.Fatalf("POST /count-solutions status = %d, count = %+v, want 2 solutions", status, count)
+	}
+
+	var first, second generateResponse
+	do(t, ts, http.MethodGet, "/generate?difficulty=medium&symmetry=rotational&seed=7", "", &first)
+	if status := do(t, ts, http.MethodGet, "/generate?difficulty=medium&symmetry=rotational&seed=7", "", &second); status != http.StatusOK {
+		t.Fatalf("GET /generate status = %d, want 200", status)
+	}
+	if first.Puzzle == "" || first != second || first.Difficulty != "Medium" {
+		t.Fatalf("GET /generate = %+v and %+v, want the same medium puzzle", first, second)
+	}
+	if status := do(t, ts, http.MethodGet, "/generate?difficulty=impossible", "", nil); status != http.StatusBadRequest {
+		t.Fatalf("GET /generate with unknown difficulty status = %d, want 400", status)
+	}
+	if status := do(t, ts, http.MethodGet, "/solve", "", nil); status != http.StatusMethodNotAllowed {
+		t.Fatalf("GET /solve status = %d, want 405", status)
+	}
+}
+
+func TestServerLimitsAndProbes(t *testing.T) {
+	handler, ts := newTestServer(t, Config{MaxConcurrent: 1, Timeout: time.Nanosecond})
+
+	var response errorResponse
+	if status := do(t, ts, http.MethodPost, "/solve", `{"puzzle":"`+easyBoard+`"}`, &response); status != http.StatusGatewayTimeout {
+		t.Fatalf("POST /solve with an expired timeout status = %d, want 504", status)
+	}
+	if response.Error == nil || response.Error.Type != solver.CanceledErrorType {
+		t.Fatalf("POST /solve error = %+v, want canceled", response.Error)
+	}
+
+	// Waiting for the timed out solve to release its slot, then taking the only slot
+	handler.slots <- struct{}{}
+	if status := do(t, ts, http.MethodPost, "/solve", `{"puzzle":"`+easyBoard+`"}`, &response); status != http.StatusServiceUnavailable || response.Error.Type != OverloadedErrorType {
+		t.Fatalf("POST /solve without a free slot status = %d, error = %+v, want 503 overloaded", status, response.Error)
+	}
+	<-handler.slots
+
+	if status := do(t, ts, http.MethodGet, "/healthz", "", nil); status != http.StatusOK {
+		t.Fatalf("GET /healthz status = %d, want 200", status)
+	}
+	if status := do(t, ts, http.MethodGet, "/readyz", "", nil); status != http.StatusOK {
+		t.Fatalf("GET /readyz status = %d, want 200", status)
+	}
+	handler.SetReady(false)
+	if status := do(t, ts, http.MethodGet, "/readyz", "", nil); status != http.StatusServiceUnavailable {
+		t.Fatalf("GET /readyz after SetReady(false) status = %d, want 503", status)
+	}
+}
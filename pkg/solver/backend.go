@@ -0,0 +1,74 @@
+package solver
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Backend is the search used once the strategies stall and by Solver.CountSolutions
+type Backend int
+
+const (
+	// BitBoardBackend is the bitboard solver propagating the singles, see SolveGrid
+	BitBoardBackend Backend = iota
+	// BackTrackBackend is the minimum-remaining-values backtracker, see BackTrack
+	BackTrackBackend
+	// DLXBackend is the Dancing Links exact cover solver of the classic Sudoku, see DLX
+	DLXBackend
+)
+
+var Backends = map[Backend]string{
+	BitBoardBackend:  "bitboard",
+	BackTrackBackend: "backtrack",
+	DLXBackend:       "dlx",
+}
+
+// classicDLX is the DLX solver of the classic Sudoku units, it keeps no state between the searches
+var classicDLX, _ = NewDLX(Variant{})
+
+func (b Backend) String() string {
+	return Backends[b]
+}
+
+// ParseBackend returns the backend with the given name, e.g. "dlx"
+func ParseBackend(name string) (Backend, error) {
+	for backend, backendName := range Backends {
+		if strings.EqualFold(name, backendName) {
+			return backend, nil
+		}
+	}
+	return BitBoardBackend, fmt.Errorf("unknown backend: %q", name)
+}
+
+// solve returns the first solution of the grid values found by the backend
+func (b Backend) solve(ctx context.Context, grid Grid) (Grid, bool, error) {
+	switch b {
+	case BackTrackBackend:
+		if grid.Conflict() != nil {
+			return grid, false, nil
+		}
+		solved, err := backTrackGrid(ctx, &grid)
+		return grid, solved, err
+	case DLXBackend:
+		return classicDLX.Solve(ctx, grid)
+	}
+	return SolveGrid(ctx, grid)
+}
+
+// count counts the solutions of the grid values with the backend until limit solutions are found or the context is
+// done
+func (b Backend) count(ctx context.Context, grid Grid, limit int) (int, error) {
+	switch b {
+	case BackTrackBackend:
+		if limit <= 0 || grid.Conflict() != nil {
+			return 0, nil
+		}
+		count := 0
+		err := countGridMRV(ctx, &grid, limit, &count)
+		return count, err
+	case DLXBackend:
+		return classicDLX.CountContext(ctx, grid, limit)
+	}
+	return countGridSolutions(ctx, grid, limit)
+}
@@ -1,63 +1,132 @@
 package solver
 
+import (
+	"context"
+	"math/rand/v2"
+)
+
 func BackTrack(data [BoardSize][BoardSize]*Cell) (bool, [BoardSize][BoardSize]*Cell) {
-	solved, valid, row, col, candidates := nextBacktrackCell(data)
+	solved, solution, _ := BackTrackContext(context.Background(), data)
+	return solved, solution
+}
+
+// BackTrackContext searches a solution like BackTrack, but stops with the context error as soon as the context is done
+func BackTrackContext(ctx context.Context, data [BoardSize][BoardSize]*Cell) (bool, [BoardSize][BoardSize]*Cell, error) {
+	grid := gridOfData(data)
+	solved, err := backTrackGrid(ctx, &grid)
+	if err != nil || !solved {
+		return false, [BoardSize][BoardSize]*Cell{}, err
+	}
+	for id, value := range grid.Values {
+		data[id/BoardSize][id%BoardSize].Value = value
+	}
+	return true, data, nil
+}
+
+// backTrackGrid searches a solution of the grid in place with the MRV heuristic, the grid keeps the solution when
+// it returns true and the state it was given otherwise
+func backTrackGrid(ctx context.Context, grid *Grid) (bool, error) {
+	if err := canceled(ctx); err != nil {
+		return false, err
+	}
+	solved, valid, id, candidates := nextGridCell(grid)
 	if solved {
-		return true, data
+		return true, nil
 	}
 	if !valid {
-		return false, [BoardSize][BoardSize]*Cell{}
+		return false, nil
 	}
-	for _, num := range candidates.ToArray() {
-		value, ok := valueFromDigit(num)
-		if !ok {
-			return false, [BoardSize][BoardSize]*Cell{}
+	for marks := candidates; !marks.IsEmpty(); marks &= marks - 1 {
+		grid.Values[id] = marks.lowest()
+		solved, err := backTrackGrid(ctx, grid)
+		if err != nil {
+			grid.Values[id] = EmptyCellValue
+			return false, err
 		}
-		data[row][col].Value = value
-		solved, solution := BackTrack(data)
 		if solved {
-			return true, solution
+			return true, nil
+		}
+	}
+	grid.Values[id] = EmptyCellValue
+	return false, nil
+}
+
+// CountSolutions counts the solutions of the board's current state with the bitboard solver, the search stops as
+// soon as limit solutions are found
+func CountSolutions(board *Board, limit int) int {
+	return CountGridSolutions(board.Grid(), limit)
+}
+
+// HasUniqueSolution reports whether the board's current state has exactly one solution
+func HasUniqueSolution(board *Board) bool {
+	return CountSolutions(board, 2) == 1
+}
+
+// countGridMRV counts the solutions of the grid with the MRV search of backTrackGrid until limit solutions are found
+// or the context is done
+func countGridMRV(ctx context.Context, grid *Grid, limit int, count *int) error {
+	if err := canceled(ctx); err != nil {
+		return err
+	}
+	solved, valid, id, candidates := nextGridCell(grid)
+	if solved {
+		*count++
+		return nil
+	}
+	if !valid {
+		return nil
+	}
+	for marks := candidates; !marks.IsEmpty() && *count < limit; marks &= marks - 1 {
+		grid.Values[id] = marks.lowest()
+		if err := countGridMRV(ctx, grid, limit, count); err != nil {
+			grid.Values[id] = EmptyCellValue
+			return err
 		}
-		data[row][col].Value = EmptyCellValue
 	}
-	return false, [BoardSize][BoardSize]*Cell{}
+	grid.Values[id] = EmptyCellValue
+	return nil
+}
+
+// RandomGrid returns a random completely solved grid, the candidates of each cell are tried in the order given by rng
+func RandomGrid(rng *rand.Rand) [BoardSize][BoardSize]Value {
+	grid := randomGrid(rng)
+	return grid.Matrix()
 }
 
-func nextBacktrackCell(data [BoardSize][BoardSize]*Cell) (bool, bool, int, int, CandidateSet) {
+// nextGridCell returns the empty cell having the fewest candidates with its candidates. solved is true when the grid
+// has no empty cell left and no conflict, valid is false when an empty cell has no candidate
+func nextGridCell(grid *Grid) (bool, bool, int, CandidateSet) {
 	bestCount := BoardSize + 1
-	bestRow, bestCol := -1, -1
+	bestID := -1
 	var bestMarks CandidateSet
 
-	for i := 0; i < BoardSize; i++ {
-		for j := 0; j < BoardSize; j++ {
-			cell := data[i][j]
-			if cell.IsSolved() {
-				continue
-			}
+	for id, value := range grid.Values {
+		if value != EmptyCellValue {
+			continue
+		}
 
-			marks := candidateSetForPosition(data, i, j)
-			if marks.IsEmpty() {
-				return false, false, -1, -1, 0
-			}
+		marks := grid.Candidates(id)
+		if marks.IsEmpty() {
+			return false, false, -1, 0
+		}
 
-			count := marks.GetCardinality()
-			if count < bestCount {
-				bestCount = count
-				bestRow = i
-				bestCol = j
-				bestMarks = marks
-				if count == 1 {
-					return false, true, bestRow, bestCol, bestMarks
-				}
+		count := marks.GetCardinality()
+		if count < bestCount {
+			bestCount = count
+			bestID = id
+			bestMarks = marks
+			if count == 1 {
+				return false, true, bestID, bestMarks
 			}
 		}
 	}
 
-	if bestRow == -1 {
-		return !hasConflictingValues(data), !hasConflictingValues(data), -1, -1, 0
+	if bestID == -1 {
+		valid := grid.Conflict() == nil
+		return valid, valid, -1, 0
 	}
 
-	return false, true, bestRow, bestCol, bestMarks
+	return false, true, bestID, bestMarks
 }
 
 func IsValidValue(data [BoardSize][BoardSize]*Cell, row int, col int, value Value) bool {
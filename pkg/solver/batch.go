@@ -0,0 +1,119 @@
+package solver
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// BatchOptions is the configuration of SolveAll
+type BatchOptions struct {
+	// Solver solves the boards, the default pipeline is used when it is nil
+	Solver *Solver
+	// Workers is the number of boards solved in parallel, runtime.NumCPU() is used when it is not positive
+	Workers int
+	// Timeout limits the solve of each board, 0 means no limit
+	Timeout time.Duration
+	// Ordered streams the results in the input order instead of the completion order
+	Ordered bool
+	// Progress is called after each board is solved, the calls are never concurrent
+	Progress func(Progress)
+}
+
+// Progress is the state of a SolveAll call after a board is solved
+type Progress struct {
+	Completed int
+	Total     int
+	Solved    int
+	Elapsed   time.Duration
+}
+
+// BatchResult is the solve result of the board at Index of the input
+type BatchResult struct {
+	Index    int
+	Response *SolveResponse
+}
+
+// SolveAll solves the boards with a pool of workers and streams a result for each board. A worker picks the next
+// board as soon as it finishes the previous one, so a slow board only keeps its own worker busy. The channel is
+// closed after the last result and has to be drained; when the context is done the remaining boards finish quickly
+// with a *CanceledError
+func SolveAll(ctx context.Context, boards []*Board, options BatchOptions) <-chan BatchResult {
+	s := options.Solver
+	if s == nil {
+		s, _ = New()
+	}
+	workers := options.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	workers = max(1, min(workers, len(boards)))
+
+	indexes := make(chan int, len(boards))
+	for index := range boards {
+		indexes <- index
+	}
+	close(indexes)
+
+	// completed is buffered for every board so the workers never wait for the consumer
+	completed := make(chan BatchResult, len(boards))
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				completed <- BatchResult{Index: index, Response: solveWithTimeout(ctx, s, boards[index], options.Timeout)}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(completed)
+	}()
+
+	results := make(chan BatchResult)
+	go func() {
+		defer close(results)
+		begin := time.Now()
+		progress := Progress{Total: len(boards)}
+		pending := make(map[int]BatchResult)
+		next := 0
+		for result := range completed {
+			progress.Completed++
+			if result.Response.IsSolved {
+				progress.Solved++
+			}
+			progress.Elapsed = time.Since(begin)
+			if options.Progress != nil {
+				options.Progress(progress)
+			}
+			if !options.Ordered {
+				results <- result
+				continue
+			}
+			// The results completed before the previous boards wait until they can be sent in the input order
+			pending[result.Index] = result
+			for {
+				ready, ok := pending[next]
+				if !ok {
+					break
+				}
+				delete(pending, next)
+				next++
+				results <- ready
+			}
+		}
+	}()
+	return results
+}
+
+func solveWithTimeout(ctx context.Context, s *Solver, board *Board, timeout time.Duration) *SolveResponse {
+	if timeout <= 0 {
+		return s.SolveContext(ctx, board)
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return s.SolveContext(ctx, board)
+}
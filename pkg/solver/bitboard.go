@@ -0,0 +1,220 @@
+package solver
+
+import (
+	"context"
+	"math/rand/v2"
+)
+
+// cancelCheckInterval is the number of search nodes visited between the checks of the context
+const cancelCheckInterval = 1024
+
+// bitBoard is the state of the brute-force solver. Each unit keeps the mask of the digits already placed in it, so
+// the candidates of a cell are the digits missing from the masks of its row, col and box. The state is small enough
+// to be copied on every branch instead of undoing the placements
+type bitBoard struct {
+	values [CellCount]Value
+	used   [UnitCount]CandidateSet
+	empty  int
+}
+
+// bitSearch keeps the state shared by the branches of a search
+type bitSearch struct {
+	ctx      context.Context
+	limit    int
+	count    int
+	nodes    int
+	rng      *rand.Rand
+	solution [CellCount]Value
+	err      error
+}
+
+// newBitBoard returns the bitboard of the grid values, false when a unit repeats a value
+func newBitBoard(grid *Grid) (bitBoard, bool) {
+	var b bitBoard
+	for id, value := range grid.Values {
+		if value == EmptyCellValue {
+			b.empty++
+			continue
+		}
+		if value > Value(BoardSize) || !b.candidates(id).Contains(int(value)) {
+			return b, false
+		}
+		b.place(id, value)
+	}
+	return b, true
+}
+
+func (b *bitBoard) candidates(id int) CandidateSet {
+	units := &cellUnitIDs[id]
+	return Digits &^ (b.used[units[0]] | b.used[units[1]] | b.used[units[2]])
+}
+
+func (b *bitBoard) place(id int, value Value) {
+	b.values[id] = value
+	digit := CandidateSet(1) << value
+	for _, unit := range cellUnitIDs[id] {
+		b.used[unit] |= digit
+	}
+}
+
+// propagate places the naked and the hidden singles until none is left, it returns false when a cell or a digit of
+// a unit has no place left
+func (b *bitBoard) propagate() bool {
+	for b.empty > 0 {
+		placed := false
+		for id, value := range b.values {
+			if value != EmptyCellValue {
+				continue
+			}
+			candidates := b.candidates(id)
+			if candidates.IsEmpty() {
+				return false
+			}
+			if candidates&(candidates-1) == 0 {
+				b.place(id, candidates.lowest())
+				b.empty--
+				placed = true
+			}
+		}
+		for unit := 0; unit < UnitCount && b.empty > 0; unit++ {
+			var once, twice CandidateSet
+			for _, id := range unitIDs[unit] {
+				if b.values[id] == EmptyCellValue {
+					candidates := b.candidates(id)
+					twice |= once & candidates
+					once |= candidates
+				}
+			}
+			if once|b.used[unit] != Digits {
+				return false
+			}
+			hidden := once &^ twice
+			if hidden.IsEmpty() {
+				continue
+			}
+			for _, id := range unitIDs[unit] {
+				if b.values[id] != EmptyCellValue {
+					continue
+				}
+				if digit := b.candidates(id) & hidden; !digit.IsEmpty() {
+					if digit&(digit-1) != 0 {
+						// A cell can't hold two hidden singles of the same unit
+						return false
+					}
+					b.place(id, digit.lowest())
+					b.empty--
+					placed = true
+				}
+			}
+		}
+		if !placed {
+			return true
+		}
+	}
+	return true
+}
+
+// nextCell returns the empty cell having the fewest candidates
+func (b *bitBoard) nextCell() (int, CandidateSet) {
+	best, bestCount := -1, BoardSize+1
+	var bestCandidates CandidateSet
+	for id, value := range b.values {
+		if value != EmptyCellValue {
+			continue
+		}
+		candidates := b.candidates(id)
+		if count := candidates.GetCardinality(); count < bestCount {
+			best, bestCount, bestCandidates = id, count, candidates
+			if count == 2 {
+				break
+			}
+		}
+	}
+	return best, bestCandidates
+}
+
+// search counts the solutions of the board until the limit is reached, the first solution is kept
+func (s *bitSearch) search(b bitBoard) {
+	if s.nodes%cancelCheckInterval == 0 && s.ctx != nil {
+		if err := canceled(s.ctx); err != nil {
+			s.err = err
+		}
+	}
+	s.nodes++
+	if s.err != nil || !b.propagate() {
+		return
+	}
+	if b.empty == 0 {
+		if s.count == 0 {
+			s.solution = b.values
+		}
+		s.count++
+		return
+	}
+	id, candidates := b.nextCell()
+	digits := candidates.ToArray()
+	if s.rng != nil {
+		s.rng.Shuffle(len(digits), func(i, j int) {
+			digits[i], digits[j] = digits[j], digits[i]
+		})
+	}
+	for _, digit := range digits {
+		next := b
+		next.place(id, Value(digit))
+		next.empty--
+		s.search(next)
+		if s.count >= s.limit || s.err != nil {
+			return
+		}
+	}
+}
+
+// SolveGrid solves the values of the grid with the bitboard solver, which propagates the naked and hidden singles
+// and branches on the cell having the fewest candidates. The marks of the grid are ignored. It returns the solved
+// grid and true, or false when the values have no solution; the context is checked periodically
+func SolveGrid(ctx context.Context, grid Grid) (Grid, bool, error) {
+	b, ok := newBitBoard(&grid)
+	if !ok {
+		return grid, false, nil
+	}
+	s := &bitSearch{ctx: ctx, limit: 1}
+	s.search(b)
+	if s.err != nil {
+		return grid, false, s.err
+	}
+	if s.count == 0 {
+		return grid, false, nil
+	}
+	solved := Grid{Values: s.solution}
+	return solved, true, nil
+}
+
+// CountGridSolutions counts the solutions of the grid values with the bitboard solver, the search stops as soon as
+// limit solutions are found
+func CountGridSolutions(grid Grid, limit int) int {
+	count, _ := countGridSolutions(context.Background(), grid, limit)
+	return count
+}
+
+// countGridSolutions is CountGridSolutions checking the context periodically
+func countGridSolutions(ctx context.Context, grid Grid, limit int) (int, error) {
+	if limit <= 0 {
+		return 0, nil
+	}
+	b, ok := newBitBoard(&grid)
+	if !ok {
+		return 0, nil
+	}
+	s := &bitSearch{ctx: ctx, limit: limit}
+	s.search(b)
+	return s.count, s.err
+}
+
+// randomGrid returns a random solved grid, the candidates of each branch are tried in the order given by rng
+func randomGrid(rng *rand.Rand) Grid {
+	var empty Grid
+	b, _ := newBitBoard(&empty)
+	s := &bitSearch{limit: 1, rng: rng}
+	s.search(b)
+	return Grid{Values: s.solution}
+}
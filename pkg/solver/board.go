@@ -1,8 +1,9 @@
 package solver
 
 import (
-	"errors"
+	"context"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 )
@@ -34,6 +35,16 @@ var Levels = map[Difficulty]string{
 	Evil:   "Evil",
 }
 
+// ParseDifficulty returns the difficulty with the given name ignoring the case, e.g. "hard"
+func ParseDifficulty(name string) (Difficulty, error) {
+	for difficulty, level := range Levels {
+		if strings.EqualFold(name, level) {
+			return difficulty, nil
+		}
+	}
+	return Easy, fmt.Errorf("unknown difficulty: %q", name)
+}
+
 type StrategyName string
 
 const (
@@ -43,12 +54,24 @@ const (
 	LockedCandidatesStrategy StrategyName = "Locked Candidates"
 	XYWingsStrategy          StrategyName = "XY Wings"
 	XYZWingsStrategy         StrategyName = "XYZ Wings"
+	WWingsStrategy           StrategyName = "W Wings"
+	XYChainsStrategy         StrategyName = "XY Chains"
 	XWingsStrategy           StrategyName = "X Wings"
 	SwordFishStrategy        StrategyName = "Sword Fish"
+	JellyfishStrategy        StrategyName = "Jellyfish"
+	FinnedXWingsStrategy     StrategyName = "Finned X Wings"
+	FinnedSwordFishStrategy  StrategyName = "Finned Sword Fish"
+	FinnedJellyfishStrategy  StrategyName = "Finned Jellyfish"
+	SkyscraperStrategy       StrategyName = "Skyscraper"
+	TwoStringKiteStrategy    StrategyName = "2-String Kite"
+	EmptyRectangleStrategy   StrategyName = "Empty Rectangle"
+	ColoringStrategy         StrategyName = "Coloring"
 	HiddenSingleStrategy     StrategyName = "Hidden Single"
 	HiddenQuadsStrategy      StrategyName = "Hidden Quads"
 	HiddenTripletsStrategy   StrategyName = "Hidden Triplets"
 	HiddenPairsStrategy      StrategyName = "Hidden Pairs"
+	NakedSingleStrategy      StrategyName = "Naked Single"
+	BackTrackingStrategy     StrategyName = "Back Tracking"
 )
 
 func (s StrategyName) String() string {
@@ -80,46 +103,48 @@ func valueFromDigit(digit int) (Value, bool) {
 	return digitValues[digit], true
 }
 
-// Board is the struct of the Sudoku board
+// Board is the struct of the Sudoku board. The cells are kept in a single array, data and the units point into it
+// so the strategies get the rows, cols and boxes without any allocation
 type Board struct {
+	cells          [CellCount]Cell
 	data           [BoardSize][BoardSize]*Cell
+	units          [UnitCount][BoardSize]*Cell
+	cellUnits      [CellCount][3][]*Cell
+	input          [BoardSize][BoardSize]Value
 	initialState   string
 	difficulty     Difficulty
 	givens         int
 	backTrackUsed  bool
 	strategiesUsed []string
+	trace          []Deduction
+	rating         *Rating
+	stall          *Stall
+	// keepMarks is set for the boards loaded from marks/candidates, their marks are narrowed instead of recomputed
+	keepMarks bool
 }
 
 // NewBoard returns new Sudoku board with the given input matrix, if there are any issues it also returns error
 func NewBoard(input [BoardSize][BoardSize]Value) (*Board, error) {
-	var data [BoardSize][BoardSize]*Cell
+	return newBoard(input, MinimumGivens)
+}
+
+// newBoard returns the board of the input matrix rejecting the inputs with less than minimum givens
+func newBoard(input [BoardSize][BoardSize]Value, minimum int) (*Board, error) {
+	grid := GridOf(input)
 	givens := 0
-	id := 0
-	for i := 0; i < BoardSize; i++ {
-		for j := 0; j < BoardSize; j++ {
-			value := input[i][j]
-			if value > Value(BoardSize) {
-				return nil, fmt.Errorf("%d is not valid input at [%d][%d]", value, i, j)
-			}
-			cell := &Cell{
-				ID:    id,
-				Row:   i,
-				Col:   j,
-				Value: input[i][j],
-				Marks: 0,
-			}
-			data[i][j] = cell
-			id++
-			if value != EmptyCellValue {
-				givens++
-			}
+	for id, value := range grid.Values {
+		if value > Value(BoardSize) {
+			return nil, &InvalidValueError{Cell: refOfID(id), Value: value}
+		}
+		if value != EmptyCellValue {
+			givens++
 		}
 	}
-	if hasConflictingValues(data) {
-		return nil, errors.New("board contains conflicting givens")
+	if conflict := grid.Conflict(); conflict != nil {
+		return nil, conflict
 	}
-	if givens < MinimumGivens {
-		return nil, fmt.Errorf("at least %d cells should be given to find out unique solution; current givens: %d", MinimumGivens, givens)
+	if givens < minimum {
+		return nil, &TooFewGivensError{Givens: givens, Minimum: minimum}
 	}
 	var difficulty Difficulty
 	if givens > 32 {
@@ -138,24 +163,77 @@ func NewBoard(input [BoardSize][BoardSize]Value) (*Board, error) {
 		difficulty = Evil
 	}
 	board := &Board{
-		data:           data,
+		input:          input,
 		initialState:   "",
 		difficulty:     difficulty,
 		givens:         givens,
 		backTrackUsed:  false,
 		strategiesUsed: make([]string, 0),
+		trace:          make([]Deduction, 0),
 	}
+	board.setGrid(&grid)
 	// Storing the initial state before Solve method is called
 	board.initialState = board.getState()
 
 	return board, nil
 }
 
+// setGrid sets the cells to the state of the grid and links data and the units to the cells
+func (b *Board) setGrid(grid *Grid) {
+	for id := range b.cells {
+		b.cells[id] = Cell{ID: id, Row: id / BoardSize, Col: id % BoardSize, Value: grid.Values[id], Marks: grid.Marks[id]}
+		b.data[id/BoardSize][id%BoardSize] = &b.cells[id]
+	}
+	for unit, ids := range unitIDs {
+		for i, id := range ids {
+			b.units[unit][i] = &b.cells[id]
+		}
+	}
+	for id, units := range cellUnitIDs {
+		for i, unit := range units {
+			b.cellUnits[id][i] = b.units[unit][:]
+		}
+	}
+}
+
 // GetGivensAndBackTrack returns the givens and backTrackUsed flag
 func (b *Board) GetGivensAndBackTrack() (int, bool) {
 	return b.givens, b.backTrackUsed
 }
 
+// Givens returns the input values of the board, the empty cells are EmptyCellValue
+func (b *Board) Givens() [BoardSize][BoardSize]Value {
+	return b.input
+}
+
+// Values returns the current values of the board, the unsolved cells are EmptyCellValue
+func (b *Board) Values() [BoardSize][BoardSize]Value {
+	var values [BoardSize][BoardSize]Value
+	for i := 0; i < BoardSize; i++ {
+		for j := 0; j < BoardSize; j++ {
+			values[i][j] = b.data[i][j].Value
+		}
+	}
+	return values
+}
+
+// Cell returns the cell in the given row and col ids, it returns nil for the indexes out of the board
+func (b *Board) Cell(row int, col int) *Cell {
+	if row < 0 || row >= BoardSize || col < 0 || col >= BoardSize {
+		return nil
+	}
+	return b.data[row][col]
+}
+
+// Units returns copies of all rows, cols and boxes of the board in this order, the cells are the board's own ones
+func (b *Board) Units() [][]*Cell {
+	units := make([][]*Cell, 0, UnitCount)
+	for unit := range b.units {
+		units = append(units, slices.Clone(b.units[unit][:]))
+	}
+	return units
+}
+
 // addStrategy
 func (b *Board) addStrategy(strategy StrategyName) {
 	for _, s := range b.strategiesUsed {
@@ -170,28 +248,34 @@ func (b *Board) addStrategy(strategy StrategyName) {
 
 // row returns the row in the given index
 func (b *Board) row(index int) []*Cell {
-	return Row(b.data, index)
+	if index < 0 || index >= BoardSize {
+		return make([]*Cell, 0)
+	}
+	return b.units[index][:]
 }
 
 // col returns the col in the given index
 func (b *Board) col(index int) []*Cell {
-	return Col(b.data, index)
+	if index < 0 || index >= BoardSize {
+		return make([]*Cell, 0)
+	}
+	return b.units[BoardSize+index][:]
 }
 
 // box returns the box cells in the given cell index by row and col ids
 func (b *Board) box(rowID int, colID int) []*Cell {
-	return Box(b.data, rowID, colID)
+	if (rowID < 0 || rowID >= BoardSize) || (colID < 0 || colID >= BoardSize) {
+		return make([]*Cell, 0)
+	}
+	return b.units[2*BoardSize+boxIndex(rowID, colID)][:]
 }
 
 // emptyCells returns the number of the unsolved cells
 func (b *Board) emptyCells() int {
 	empty := 0
-	for i := 0; i < BoardSize; i++ {
-		for j := 0; j < BoardSize; j++ {
-			cell := b.data[i][j]
-			if !cell.IsSolved() {
-				empty++
-			}
+	for id := range b.cells {
+		if !b.cells[id].IsSolved() {
+			empty++
 		}
 	}
 	return empty
@@ -263,15 +347,20 @@ func (b *Board) totalMarks() int {
 	return total
 }
 
-// computeAllMarks simply computes all marks/candidates of each unsolved cells
+// computeAllMarks simply computes all marks/candidates of each unsolved cells. The boards loaded from a pencil-mark
+// grid keep their marks, only the solved peers are removed from them
 func (b *Board) computeAllMarks() error {
 	for i := 0; i < BoardSize; i++ {
 		for j := 0; j < BoardSize; j++ {
 			cell := b.data[i][j]
 			if !cell.IsSolved() {
-				cell.Marks = cell.ComputeCellMarks(b)
+				marks := cell.ComputeCellMarks(b)
+				if b.keepMarks && !cell.Marks.IsEmpty() {
+					marks = marks.And(cell.Marks)
+				}
+				cell.Marks = marks
 				if cell.Marks.IsEmpty() {
-					return fmt.Errorf("compute all marks: cell: %+v", cell)
+					return emptyCandidates(cell, "")
 				}
 			}
 		}
@@ -285,7 +374,7 @@ func (b *Board) eliminateNP() error {
 		for j := 0; j < BoardSize; j++ {
 			cell := b.data[i][j]
 			if !cell.IsSolved() {
-				if eliminateErr := EliminateNakedPairs(cell.CellUnits(b)); eliminateErr != nil {
+				if eliminateErr := eliminateNakedPairs(cell.units(b), b.recordDeduction); eliminateErr != nil {
 					return eliminateErr
 				}
 			}
@@ -300,7 +389,7 @@ func (b *Board) eliminateNT() error {
 		for j := 0; j < BoardSize; j++ {
 			cell := b.data[i][j]
 			if !cell.IsSolved() {
-				if eliminateErr := EliminateNakedTriplets(cell.CellUnits(b)); eliminateErr != nil {
+				if eliminateErr := eliminateNakedTriplets(cell.units(b), b.recordDeduction); eliminateErr != nil {
 					return eliminateErr
 				}
 			}
@@ -315,7 +404,7 @@ func (b *Board) eliminateNQ() error {
 		for j := 0; j < BoardSize; j++ {
 			cell := b.data[i][j]
 			if !cell.IsSolved() {
-				if eliminateErr := EliminateNakedQuads(cell.CellUnits(b)); eliminateErr != nil {
+				if eliminateErr := eliminateNakedQuads(cell.units(b), b.recordDeduction); eliminateErr != nil {
 					return eliminateErr
 				}
 			}
@@ -330,7 +419,7 @@ func (b *Board) eliminateHS() error {
 		for j := 0; j < BoardSize; j++ {
 			cell := b.data[i][j]
 			if !cell.IsSolved() {
-				if eliminateErr := EliminateHiddenSingles(cell.CellUnits(b)); eliminateErr != nil {
+				if eliminateErr := eliminateHiddenSingles(cell.units(b), b.recordDeduction); eliminateErr != nil {
 					return eliminateErr
 				}
 			}
@@ -345,7 +434,7 @@ func (b *Board) eliminateHP() error {
 		for j := 0; j < BoardSize; j++ {
 			cell := b.data[i][j]
 			if !cell.IsSolved() {
-				if eliminateErr := EliminateHiddenPairs(cell.CellUnits(b)); eliminateErr != nil {
+				if eliminateErr := eliminateHiddenPairs(cell.units(b), b.recordDeduction); eliminateErr != nil {
 					return eliminateErr
 				}
 			}
@@ -360,7 +449,7 @@ func (b *Board) eliminateHT() error {
 		for j := 0; j < BoardSize; j++ {
 			cell := b.data[i][j]
 			if !cell.IsSolved() {
-				if eliminateErr := EliminateHiddenTriplets(cell.CellUnits(b)); eliminateErr != nil {
+				if eliminateErr := eliminateHiddenTriplets(cell.units(b), b.recordDeduction); eliminateErr != nil {
 					return eliminateErr
 				}
 			}
@@ -375,7 +464,7 @@ func (b *Board) eliminateHQ() error {
 		for j := 0; j < BoardSize; j++ {
 			cell := b.data[i][j]
 			if !cell.IsSolved() {
-				if eliminateErr := EliminateHiddenQuads(cell.CellUnits(b)); eliminateErr != nil {
+				if eliminateErr := eliminateHiddenQuads(cell.units(b), b.recordDeduction); eliminateErr != nil {
 					return eliminateErr
 				}
 			}
@@ -399,29 +488,86 @@ func (b *Board) eliminateXYZWings() error {
 	return EliminateXYZWings(b.unsolvedCells(), b)
 }
 
+// eliminateWWings simply eliminates marks/candidates using W Wings strategy for the board
+func (b *Board) eliminateWWings() error {
+	return EliminateWWings(b.unsolvedCells(), b)
+}
+
 // eliminateXWings simply eliminates marks/candidates using X Wings strategy for the board
 func (b *Board) eliminateXWings() error {
-	return EliminateXWings(b)
+	return EliminateFish(b, 2)
+}
+
+// eliminateSkyscrapers simply eliminates marks/candidates using Skyscraper strategy for the board
+func (b *Board) eliminateSkyscrapers() error {
+	return EliminateSkyscrapers(b)
+}
+
+// eliminateTwoStringKites simply eliminates marks/candidates using 2-String Kite strategy for the board
+func (b *Board) eliminateTwoStringKites() error {
+	return EliminateTwoStringKites(b)
+}
+
+// eliminateEmptyRectangles simply eliminates marks/candidates using Empty Rectangle strategy for the board
+func (b *Board) eliminateEmptyRectangles() error {
+	return EliminateEmptyRectangles(b)
 }
 
 // eliminateSwordFish simply eliminates marks/candidates using Sword Fish strategy for the board
 func (b *Board) eliminateSwordFish() error {
-	return EliminateSwordFish(b)
+	return EliminateFish(b, 3)
+}
+
+// eliminateColoring simply eliminates marks/candidates using simple coloring and multi-coloring for the board
+func (b *Board) eliminateColoring() error {
+	return EliminateColoring(b)
+}
+
+// eliminateXYChains simply eliminates marks/candidates using XY Chains of up to DefaultMaxChainLength cells for the board
+func (b *Board) eliminateXYChains() error {
+	return EliminateXYChains(b, DefaultMaxChainLength)
+}
+
+// eliminateJellyfish simply eliminates marks/candidates using Jellyfish strategy for the board
+func (b *Board) eliminateJellyfish() error {
+	return EliminateFish(b, 4)
+}
+
+// eliminateFinnedXWings simply eliminates marks/candidates using finned and sashimi X Wings for the board
+func (b *Board) eliminateFinnedXWings() error {
+	return EliminateFinnedFish(b, 2)
+}
+
+// eliminateFinnedSwordFish simply eliminates marks/candidates using finned and sashimi Sword Fish for the board
+func (b *Board) eliminateFinnedSwordFish() error {
+	return EliminateFinnedFish(b, 3)
+}
+
+// eliminateFinnedJellyfish simply eliminates marks/candidates using finned and sashimi Jellyfish for the board
+func (b *Board) eliminateFinnedJellyfish() error {
+	return EliminateFinnedFish(b, 4)
 }
 
 // backTrack simply tries to find out a unique solution where strategies no more producing solutions or eliminating candidates
-func (b *Board) backTrack() bool {
-	clone := CloneData(b.data)
-	solved, solution := BackTrack(clone)
+func (b *Board) backTrack(ctx context.Context, backend Backend) error {
+	grid, solved, err := backend.solve(ctx, b.Grid())
+	if err != nil {
+		return err
+	}
 	if solved {
 		b.backTrackUsed = true
+		deduction := Deduction{Strategy: BackTrackingStrategy, Placements: make([]Placement, 0)}
 		for i := 0; i < BoardSize; i++ {
 			for j := 0; j < BoardSize; j++ {
-				b.data[i][j].Value = solution[i][j].Value
-				b.data[i][j].Marks = b.data[i][j].Marks.Clear()
+				cell := b.data[i][j]
+				if !cell.IsSolved() {
+					deduction.Placements = append(deduction.Placements, Placement{Cell: refOf(cell), Value: grid.Values[cell.ID]})
+				}
+				cell.Value = grid.Values[cell.ID]
+				cell.Marks = cell.Marks.Clear()
 			}
 		}
-		return true
+		b.recordDeduction(deduction)
 	}
-	return false
+	return nil
 }
@@ -1,7 +1,5 @@
 package solver
 
-import "fmt"
-
 func (b *Board) initializeCandidates() error {
 	return b.computeAllMarks()
 }
@@ -19,7 +17,7 @@ func (b *Board) resolveSingles() (bool, error) {
 
 				solution, ok := cell.Marks.First()
 				if !ok {
-					return changed, errorsNewInvalidMarks(cell)
+					return changed, emptyCandidates(cell, NakedSingleStrategy)
 				}
 				if !cell.IsValid(b, solution) {
 					return changed, b.solveError()
@@ -27,6 +25,12 @@ func (b *Board) resolveSingles() (bool, error) {
 
 				cell.Value = solution
 				cell.Marks = cell.Marks.Clear()
+				b.recordDeduction(Deduction{
+					Strategy:   NakedSingleStrategy,
+					Pattern:    []CellRef{refOf(cell)},
+					Digits:     CandidateSetOf(int(solution)),
+					Placements: []Placement{{Cell: refOf(cell), Value: solution}},
+				})
 				changed = true
 				if err := b.computeAllMarks(); err != nil {
 					return changed, err
@@ -41,7 +45,3 @@ func (b *Board) resolveSingles() (bool, error) {
 	nextPass:
 	}
 }
-
-func errorsNewInvalidMarks(cell *Cell) error {
-	return fmt.Errorf("invalid board; empty marks: Cell: %+v", cell)
-}
@@ -1,5 +1,7 @@
 package solver
 
+import "slices"
+
 // Value is the value type of the solved cell which is simply a byte
 type Value byte
 
@@ -12,21 +14,46 @@ type Cell struct {
 	Marks CandidateSet
 }
 
-// CellUnits returns the related cells row, col and box
+// CellUnits returns copies of the related cells row, col and box, so the caller may reorder them
 func (c *Cell) CellUnits(b *Board) [][]*Cell {
-	cells := make([][]*Cell, 0)
-	cells = append(cells, b.row(c.Row), b.col(c.Col), b.box(c.Row, c.Col))
-	return cells
+	units := make([][]*Cell, 0, len(b.cellUnits[c.ID]))
+	for _, unit := range b.cellUnits[c.ID] {
+		units = append(units, slices.Clone(unit))
+	}
+	return units
+}
+
+// units returns the related cells row, col and box shared with the board, the strategies read them without any
+// allocation and shouldn't modify them
+func (c *Cell) units(b *Board) [][]*Cell {
+	return b.cellUnits[c.ID][:]
 }
 
 // ComputeCellMarks computes the candidates/marks of the current cell
 func (c *Cell) ComputeCellMarks(b *Board) CandidateSet {
-	return candidateSetForPosition(b.data, c.Row, c.Col)
+	var used CandidateSet
+	for _, peer := range peerIDs[c.ID] {
+		used |= 1 << b.cells[peer].Value
+	}
+	return Digits &^ used
 }
 
 // IsValid checks the validity of given v value to put in cell
 func (c *Cell) IsValid(b *Board, v Value) bool {
-	return IsValidValue(b.data, c.Row, c.Col, v)
+	for _, peer := range peerIDs[c.ID] {
+		if b.cells[peer].Value == v {
+			return false
+		}
+	}
+	return true
+}
+
+// Sees reports whether the cell shares a row, a col or a box with the other cell
+func (c *Cell) Sees(other *Cell) bool {
+	if c.ID == other.ID {
+		return false
+	}
+	return c.Row == other.Row || c.Col == other.Col || boxIndex(c.Row, c.Col) == boxIndex(other.Row, other.Col)
 }
 
 // IsSolved simply returns whether the cell is already solved or not
@@ -0,0 +1,101 @@
+package solver
+
+import "fmt"
+
+const (
+	// DefaultMaxChainLength is the longest XY Chain, in cells, searched by the default pipeline
+	DefaultMaxChainLength = 8
+	// minChainLength is the shortest XY Chain, three cells like an XY Wing
+	minChainLength = 3
+)
+
+// chainLink is a bivalue cell of an XY Chain with the digit it holds when the start of the chain doesn't hold the
+// chain digit, previous points back to the start
+type chainLink struct {
+	cell     *Cell
+	digit    int
+	length   int
+	previous *chainLink
+}
+
+// cells returns the cells of the chain from its start to the link
+func (l *chainLink) cells() []*Cell {
+	cells := make([]*Cell, l.length)
+	for link := l; link != nil; link = link.previous {
+		cells[link.length-1] = link.cell
+	}
+	return cells
+}
+
+// contains reports whether the cell is on the chain up to the link
+func (l *chainLink) contains(cell *Cell) bool {
+	for link := l; link != nil; link = link.previous {
+		if link.cell == cell {
+			return true
+		}
+	}
+	return false
+}
+
+// EliminateXYChains eliminates marks/candidates using XY Chains of up to maxLength bivalue cells. Each cell of the
+// chain sees the next one and shares a digit with it, so when the start doesn't hold the chain digit every cell holds
+// the digit it doesn't share with the previous one, up to the end holding the chain digit. One of the ends holds it
+// either way and the cells seeing both ends lose it
+func EliminateXYChains(b *Board, maxLength int) error {
+	if maxLength < minChainLength {
+		return fmt.Errorf("max chain length should be at least %d, found %d", minChainLength, maxLength)
+	}
+	for _, start := range b.unsolvedCells() {
+		if start.Marks.GetCardinality() != 2 {
+			continue
+		}
+		for _, digit := range start.Marks.ToArray() {
+			if err := eliminateXYChainsFrom(b, start, digit, maxLength); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// eliminateXYChainsFrom searches the chains of the digit starting at the cell breadth first, so each cell is reached
+// with each digit by the shortest chain only
+func eliminateXYChainsFrom(b *Board, start *Cell, digit int, maxLength int) error {
+	mark := CandidateSetOf(digit)
+	if start.Marks.GetCardinality() != 2 || !start.Marks.Contains(digit) {
+		return nil
+	}
+	var visited [CellCount][BoardSize + 1]bool
+	other := int(start.Marks.AndNot(mark).lowest())
+	visited[start.ID][other] = true
+	queue := []*chainLink{{cell: start, digit: other, length: 1}}
+	for len(queue) > 0 {
+		link := queue[0]
+		queue = queue[1:]
+		if link.length == maxLength {
+			continue
+		}
+		for _, id := range peerIDs[link.cell.ID] {
+			cell := &b.cells[id]
+			if cell.IsSolved() || cell.Marks.GetCardinality() != 2 || !cell.Marks.Contains(link.digit) {
+				continue
+			}
+			next := int(cell.Marks.AndNot(CandidateSetOf(link.digit)).lowest())
+			if visited[id][next] || link.contains(cell) {
+				continue
+			}
+			visited[id][next] = true
+			nextLink := &chainLink{cell: cell, digit: next, length: link.length + 1, previous: link}
+			queue = append(queue, nextLink)
+			if next != digit || nextLink.length < minChainLength {
+				continue
+			}
+			pattern := nextLink.cells()
+			targets := commonPeers(b, []*Cell{start, cell}, pattern, mark)
+			if err := eliminateFromTargets(b.recordDeduction, XYChainsStrategy, pattern, targets, mark); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+package solver
+
+// colorCluster is a cluster of coloring, the cells of a digit chained by conjugate pairs. The cells are colored in
+// turn along the chains, so either every cell of the first color holds the digit or every cell of the second one does
+type colorCluster struct {
+	colors [2][]*Cell
+}
+
+// cells returns the cells of both colors
+func (c *colorCluster) cells() []*Cell {
+	return append(append(make([]*Cell, 0, len(c.colors[0])+len(c.colors[1])), c.colors[0]...), c.colors[1]...)
+}
+
+// contains reports whether the cell belongs to one of the colors of the cluster
+func (c *colorCluster) contains(cell *Cell) bool {
+	return IsCellInCollection(cell, c.colors[0]) || IsCellInCollection(cell, c.colors[1])
+}
+
+// colorClusters chains the conjugate pairs of the mark in every unit and colors each chain in turn
+func colorClusters(b *Board, mark CandidateSet) []*colorCluster {
+	var links [CellCount][]*Cell
+	for unit := range b.units {
+		if cells := candidateCellsForMark(b.units[unit][:], mark); len(cells) == 2 {
+			links[cells[0].ID] = append(links[cells[0].ID], cells[1])
+			links[cells[1].ID] = append(links[cells[1].ID], cells[0])
+		}
+	}
+	var colored [CellCount]bool
+	var colors [CellCount]int
+	clusters := make([]*colorCluster, 0)
+	for id := range links {
+		if len(links[id]) == 0 || colored[id] {
+			continue
+		}
+		cluster := &colorCluster{}
+		colored[id], colors[id] = true, 0
+		queue := []*Cell{&b.cells[id]}
+		for len(queue) > 0 {
+			cell := queue[0]
+			queue = queue[1:]
+			color := colors[cell.ID]
+			cluster.colors[color] = append(cluster.colors[color], cell)
+			for _, linked := range links[cell.ID] {
+				if !colored[linked.ID] {
+					colored[linked.ID] = true
+					colors[linked.ID] = 1 - color
+					queue = append(queue, linked)
+				}
+			}
+		}
+		clusters = append(clusters, cluster)
+	}
+	return clusters
+}
+
+// EliminateColoring eliminates marks/candidates using simple coloring and multi-coloring. A color having two cells
+// which see each other is false, it wraps, and a cell seeing both colors of a cluster is trapped. Two clusters with a
+// color each seeing each other can't have both true, so the cells seeing the other colors of both clusters lose the
+// digit, and a color seeing both colors of the other cluster is false
+func EliminateColoring(b *Board) error {
+	for digit := 1; digit <= BoardSize; digit++ {
+		mark := CandidateSetOf(digit)
+		clusters := colorClusters(b, mark)
+		for _, cluster := range clusters {
+			if err := eliminateSimpleColoring(b, cluster, mark); err != nil {
+				return err
+			}
+		}
+		for i := range clusters {
+			for j := range clusters {
+				if i == j {
+					continue
+				}
+				if err := eliminateMultiColoring(b, clusters[i], clusters[j], mark); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// eliminateSimpleColoring applies the color wrap and the color trap of the cluster
+func eliminateSimpleColoring(b *Board, cluster *colorCluster, mark CandidateSet) error {
+	record := clusterRecord(b, cluster)
+	for _, color := range cluster.colors {
+		if !seeEachOther(color) {
+			continue
+		}
+		if err := eliminateFromTargets(record, ColoringStrategy, cluster.cells(), candidateCellsForMark(color, mark), mark); err != nil {
+			return err
+		}
+	}
+	targets := make([]*Cell, 0)
+	for _, cell := range b.unsolvedCells() {
+		if ParIntersect(cell.Marks, mark).IsEmpty() || cluster.contains(cell) {
+			continue
+		}
+		if seesAny(cell, cluster.colors[0]) && seesAny(cell, cluster.colors[1]) {
+			targets = append(targets, cell)
+		}
+	}
+	return eliminateFromTargets(record, ColoringStrategy, cluster.cells(), targets, mark)
+}
+
+// eliminateMultiColoring applies the eliminations of the colors of the first cluster seeing the ones of the second one
+func eliminateMultiColoring(b *Board, first *colorCluster, second *colorCluster, mark CandidateSet) error {
+	record := clusterRecord(b, first, second)
+	pattern := append(first.cells(), second.cells()...)
+	for i, color := range first.colors {
+		seen := [2]bool{colorsSee(color, second.colors[0]), colorsSee(color, second.colors[1])}
+		if seen[0] && seen[1] {
+			if err := eliminateFromTargets(record, ColoringStrategy, pattern, candidateCellsForMark(color, mark), mark); err != nil {
+				return err
+			}
+			continue
+		}
+		for j := range seen {
+			if !seen[j] {
+				continue
+			}
+			// The colors seeing each other can't be both true, so the other color of one of the clusters holds the digit
+			targets := make([]*Cell, 0)
+			for _, cell := range b.unsolvedCells() {
+				if ParIntersect(cell.Marks, mark).IsEmpty() || first.contains(cell) || second.contains(cell) {
+					continue
+				}
+				if seesAny(cell, first.colors[1-i]) && seesAny(cell, second.colors[1-j]) {
+					targets = append(targets, cell)
+				}
+			}
+			if err := eliminateFromTargets(record, ColoringStrategy, pattern, targets, mark); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// clusterRecord returns the record function adding the given clusters to the deductions
+func clusterRecord(b *Board, clusters ...*colorCluster) recordFunc {
+	return func(deduction Deduction) {
+		deduction.Clusters = make([]ColorCluster, 0, len(clusters))
+		for _, cluster := range clusters {
+			deduction.Clusters = append(deduction.Clusters, ColorCluster{Colors: [2][]CellRef{refsOf(cluster.colors[0]), refsOf(cluster.colors[1])}})
+		}
+		b.recordDeduction(deduction)
+	}
+}
+
+// seeEachOther reports whether two of the cells share a unit
+func seeEachOther(cells []*Cell) bool {
+	for i := 0; i < len(cells)-1; i++ {
+		if seesAny(cells[i], cells[i+1:]) {
+			return true
+		}
+	}
+	return false
+}
+
+// seesAny reports whether the cell shares a unit with one of the given cells
+func seesAny(cell *Cell, cells []*Cell) bool {
+	for _, other := range cells {
+		if cell.Sees(other) {
+			return true
+		}
+	}
+	return false
+}
+
+// colorsSee reports whether a cell of the first color shares a unit with a cell of the second one
+func colorsSee(first []*Cell, second []*Cell) bool {
+	for _, cell := range first {
+		if seesAny(cell, second) {
+			return true
+		}
+	}
+	return false
+}
@@ -1,48 +1,73 @@
 package solver
 
+// combinationCount returns the number of the k sized combinations of n elements
+func combinationCount(n int, k int) int {
+	if n < k {
+		return 0
+	}
+	count := 1
+	for i := 0; i < k; i++ {
+		count = count * (n - i) / (i + 1)
+	}
+	return count
+}
+
+// cellCombinations returns the combination slices of the given size, the combinations share a single backing array
+// and are capped at their size so appending to one doesn't overwrite the next one
+type cellCombinations struct {
+	size    int
+	cells   []*Cell
+	results [][]*Cell
+}
+
+func newCellCombinations(n int, size int) *cellCombinations {
+	count := combinationCount(n, size)
+	return &cellCombinations{size: size, cells: make([]*Cell, 0, count*size), results: make([][]*Cell, 0, count)}
+}
+
+func (c *cellCombinations) add(cells ...*Cell) {
+	start := len(c.cells)
+	c.cells = append(c.cells, cells...)
+	c.results = append(c.results, c.cells[start:start+c.size:start+c.size])
+}
+
 // PairCombinations simply creates all unique ordered pair combinations of given cell unit
 func PairCombinations(input []*Cell) [][]*Cell {
-	pairs := make([][]*Cell, 0)
+	pairs := newCellCombinations(len(input), 2)
 	for i := 0; i < len(input)-1; i++ {
 		for j := i + 1; j < len(input); j++ {
-			pair := make([]*Cell, 0, 2)
-			pair = append(pair, input[i], input[j])
-			pairs = append(pairs, pair)
+			pairs.add(input[i], input[j])
 		}
 	}
-	return pairs
+	return pairs.results
 }
 
 // TripletCombinations simply creates all unique ordered triple combinations of given cells unit
 func TripletCombinations(input []*Cell) [][]*Cell {
-	triplets := make([][]*Cell, 0)
+	triplets := newCellCombinations(len(input), 3)
 	for i := 0; i < len(input)-2; i++ {
 		for j := i + 1; j < len(input)-1; j++ {
 			for k := j + 1; k < len(input); k++ {
-				triplet := make([]*Cell, 0, 3)
-				triplet = append(triplet, input[i], input[j], input[k])
-				triplets = append(triplets, triplet)
+				triplets.add(input[i], input[j], input[k])
 			}
 		}
 	}
-	return triplets
+	return triplets.results
 }
 
 // QuadCombinations simply creates all unique ordered quad combinations of given cells unit
 func QuadCombinations(input []*Cell) [][]*Cell {
-	quads := make([][]*Cell, 0)
+	quads := newCellCombinations(len(input), 4)
 	for i := 0; i < len(input)-3; i++ {
 		for j := i + 1; j < len(input)-2; j++ {
 			for k := j + 1; k < len(input)-1; k++ {
 				for l := k + 1; l < len(input); l++ {
-					quad := make([]*Cell, 0, 4)
-					quad = append(quad, input[i], input[j], input[k], input[l])
-					quads = append(quads, quad)
+					quads.add(input[i], input[j], input[k], input[l])
 				}
 			}
 		}
 	}
-	return quads
+	return quads.results
 }
 
 // BitmapSingles simply creates all unique ordered single combinations in given int array as CandidateSet instances.
@@ -56,7 +81,7 @@ func BitmapSingles(in []int) []CandidateSet {
 
 // BitmapPairs simply creates all unique ordered pair combinations in given int array as CandidateSet instances.
 func BitmapPairs(in []int) []CandidateSet {
-	pairs := make([]CandidateSet, 0)
+	pairs := make([]CandidateSet, 0, combinationCount(len(in), 2))
 	for i := 0; i < len(in)-1; i++ {
 		for j := i + 1; j < len(in); j++ {
 			pairs = append(pairs, CandidateSetOf(in[i], in[j]))
@@ -67,7 +92,7 @@ func BitmapPairs(in []int) []CandidateSet {
 
 // BitmapTriplets simply creates all unique ordered triplet combinations in given int array as CandidateSet instances.
 func BitmapTriplets(in []int) []CandidateSet {
-	triplets := make([]CandidateSet, 0)
+	triplets := make([]CandidateSet, 0, combinationCount(len(in), 3))
 	for i := 0; i < len(in)-2; i++ {
 		for j := i + 1; j < len(in)-1; j++ {
 			for k := j + 1; k < len(in); k++ {
@@ -80,7 +105,7 @@ func BitmapTriplets(in []int) []CandidateSet {
 
 // BitmapQuads simply creates all unique ordered quad combinations in given int array as CandidateSet instances.
 func BitmapQuads(in []int) []CandidateSet {
-	quads := make([]CandidateSet, 0)
+	quads := make([]CandidateSet, 0, combinationCount(len(in), 4))
 	for i := 0; i < len(in)-3; i++ {
 		for j := i + 1; j < len(in)-2; j++ {
 			for k := j + 1; k < len(in)-1; k++ {
@@ -0,0 +1,329 @@
+package solver
+
+import (
+	"context"
+	"fmt"
+)
+
+// Variant describes the units of a Sudoku variant solved by DLX. The rows and the cols are always units, the boxes
+// are units unless Regions replaces them
+type Variant struct {
+	// Diagonals adds the two main diagonals as units, as in Sudoku X
+	Diagonals bool
+	// Regions replaces the boxes with irregular regions, as in Jigsaw Sudoku. Each of the nine regions lists the ids
+	// (row*BoardSize + col) of its nine cells and every cell belongs to exactly one region
+	Regions [][]int
+	// Extra adds further units of at most nine cells, e.g. the windows of Windoku. A unit of nine cells holds every
+	// digit once, a smaller unit holds each digit at most once
+	Extra [][]int
+}
+
+// DLX solves grids as an exact cover problem with Knuth's Dancing Links. Each option places a digit in a cell, the
+// cell constraints and the digit constraints of every unit have to be covered exactly once
+type DLX struct {
+	units [][]int
+}
+
+// NewDLX returns the DLX solver of the given variant, Variant{} is the classic Sudoku
+func NewDLX(variant Variant) (*DLX, error) {
+	units := make([][]int, 0, UnitCount+2+len(variant.Extra))
+	for unit := 0; unit < 2*BoardSize; unit++ {
+		units = append(units, unitIDs[unit][:])
+	}
+	if variant.Regions == nil {
+		for unit := 2 * BoardSize; unit < UnitCount; unit++ {
+			units = append(units, unitIDs[unit][:])
+		}
+	} else {
+		if err := validateRegions(variant.Regions); err != nil {
+			return nil, err
+		}
+		units = append(units, variant.Regions...)
+	}
+	if variant.Diagonals {
+		var main, anti []int
+		for i := 0; i < BoardSize; i++ {
+			main = append(main, cellID(i, i))
+			anti = append(anti, cellID(i, BoardSize-1-i))
+		}
+		units = append(units, main, anti)
+	}
+	for index, unit := range variant.Extra {
+		if err := validateUnit(unit); err != nil {
+			return nil, fmt.Errorf("extra unit %d: %w", index, err)
+		}
+		units = append(units, unit)
+	}
+	return &DLX{units: units}, nil
+}
+
+func validateRegions(regions [][]int) error {
+	if len(regions) != BoardSize {
+		return fmt.Errorf("variant should have %d regions, found %d", BoardSize, len(regions))
+	}
+	var seen [CellCount]bool
+	for index, region := range regions {
+		if len(region) != BoardSize {
+			return fmt.Errorf("region %d should have %d cells, found %d", index, BoardSize, len(region))
+		}
+		if err := validateUnit(region); err != nil {
+			return fmt.Errorf("region %d: %w", index, err)
+		}
+		for _, id := range region {
+			if seen[id] {
+				return fmt.Errorf("region %d: cell %d belongs to another region", index, id)
+			}
+			seen[id] = true
+		}
+	}
+	return nil
+}
+
+func validateUnit(unit []int) error {
+	if len(unit) == 0 || len(unit) > BoardSize {
+		return fmt.Errorf("unit should have 1 to %d cells, found %d", BoardSize, len(unit))
+	}
+	var seen [CellCount]bool
+	for _, id := range unit {
+		if id < 0 || id >= CellCount {
+			return fmt.Errorf("invalid cell id %d", id)
+		}
+		if seen[id] {
+			return fmt.Errorf("cell %d is repeated", id)
+		}
+		seen[id] = true
+	}
+	return nil
+}
+
+// Solve returns the first solution of the grid values and true, or false when the values have no solution. The marks
+// of the grid are ignored
+func (d *DLX) Solve(ctx context.Context, grid Grid) (Grid, bool, error) {
+	var solution Grid
+	found := false
+	err := d.Enumerate(ctx, grid, func(solved Grid) bool {
+		solution, found = solved, true
+		return false
+	})
+	if err != nil || !found {
+		return grid, false, err
+	}
+	return solution, true, nil
+}
+
+// Count counts the solutions of the grid values, the search stops as soon as limit solutions are found
+func (d *DLX) Count(grid Grid, limit int) int {
+	count, _ := d.CountContext(context.Background(), grid, limit)
+	return count
+}
+
+// CountContext counts the solutions of the grid values like Count and stops with a *CanceledError when the context
+// is done
+func (d *DLX) CountContext(ctx context.Context, grid Grid, limit int) (int, error) {
+	if limit <= 0 {
+		return 0, nil
+	}
+	count := 0
+	err := d.Enumerate(ctx, grid, func(Grid) bool {
+		count++
+		return count < limit
+	})
+	return count, err
+}
+
+// Enumerate calls visit with every solution of the grid values until visit returns false. It stops with a
+// *CanceledError when the context is done
+func (d *DLX) Enumerate(ctx context.Context, grid Grid, visit func(Grid) bool) error {
+	m := d.matrix()
+	for id, value := range grid.Values {
+		if value == EmptyCellValue {
+			continue
+		}
+		if value > Value(BoardSize) || !m.selectRow(m.optionRows[id*BoardSize+int(value)-1]) {
+			// A given repeats a digit of one of its units
+			return nil
+		}
+	}
+	s := &dlxSearch{ctx: ctx, matrix: m, values: grid.Values, visit: visit}
+	s.search()
+	return s.err
+}
+
+// dlxNode is a node of the toroidal linked lists, the links are node indexes
+type dlxNode struct {
+	left, right, up, down int
+	column                int
+	option                int
+}
+
+// dlxMatrix is the Dancing Links matrix. Node 0 is the root, the column headers follow it and the option nodes follow
+// the headers. The secondary columns, the digits of the units smaller than nine cells, aren't linked to the root so
+// they are never chosen, they only keep the options covering them apart
+type dlxMatrix struct {
+	nodes      []dlxNode
+	sizes      []int
+	optionRows []int
+	covered    []bool
+}
+
+// matrix builds the matrix with an option for each digit of each cell
+func (d *DLX) matrix() *dlxMatrix {
+	// cellUnits keeps the units of each cell so the options can list their columns
+	cellUnits := make([][]int, CellCount)
+	optionNodes := 0
+	for unit, ids := range d.units {
+		for _, id := range ids {
+			cellUnits[id] = append(cellUnits[id], unit)
+		}
+		optionNodes += len(ids) * BoardSize
+	}
+	optionNodes += CellCount * BoardSize
+
+	columns := CellCount + len(d.units)*BoardSize
+	m := &dlxMatrix{
+		nodes:      make([]dlxNode, columns+1, columns+1+optionNodes),
+		sizes:      make([]int, columns+1),
+		optionRows: make([]int, CellCount*BoardSize),
+		covered:    make([]bool, columns+1),
+	}
+	m.nodes[0] = dlxNode{left: 0, right: 0}
+	for column := 1; column <= columns; column++ {
+		m.nodes[column] = dlxNode{up: column, down: column, column: column, left: column, right: column}
+		primary := column <= CellCount || len(d.units[(column-CellCount-1)/BoardSize]) == BoardSize
+		if primary {
+			last := m.nodes[0].left
+			m.nodes[column].left, m.nodes[column].right = last, 0
+			m.nodes[last].right, m.nodes[0].left = column, column
+		}
+	}
+
+	optionColumns := make([]int, 0, 1+len(d.units))
+	for id := 0; id < CellCount; id++ {
+		for digit := 0; digit < BoardSize; digit++ {
+			option := id*BoardSize + digit
+			optionColumns = append(optionColumns[:0], id+1)
+			for _, unit := range cellUnits[id] {
+				optionColumns = append(optionColumns, CellCount+unit*BoardSize+digit+1)
+			}
+			m.optionRows[option] = m.addOption(option, optionColumns)
+		}
+	}
+	return m
+}
+
+// addOption appends an option covering the given columns and returns its first node
+func (m *dlxMatrix) addOption(option int, columns []int) int {
+	first := len(m.nodes)
+	for i, column := range columns {
+		node := len(m.nodes)
+		up := m.nodes[column].up
+		m.nodes = append(m.nodes, dlxNode{left: node - 1, right: node + 1, up: up, down: column, column: column, option: option})
+		m.nodes[up].down = node
+		m.nodes[column].up = node
+		m.sizes[column]++
+		if i == 0 {
+			m.nodes[node].left = first + len(columns) - 1
+		}
+	}
+	m.nodes[len(m.nodes)-1].right = first
+	return first
+}
+
+func (m *dlxMatrix) cover(column int) {
+	m.covered[column] = true
+	header := &m.nodes[column]
+	m.nodes[header.left].right = header.right
+	m.nodes[header.right].left = header.left
+	for row := header.down; row != column; row = m.nodes[row].down {
+		for node := m.nodes[row].right; node != row; node = m.nodes[node].right {
+			n := &m.nodes[node]
+			m.nodes[n.up].down = n.down
+			m.nodes[n.down].up = n.up
+			m.sizes[n.column]--
+		}
+	}
+}
+
+func (m *dlxMatrix) uncover(column int) {
+	header := &m.nodes[column]
+	for row := header.up; row != column; row = m.nodes[row].up {
+		for node := m.nodes[row].left; node != row; node = m.nodes[node].left {
+			n := &m.nodes[node]
+			m.sizes[n.column]++
+			m.nodes[n.up].down = node
+			m.nodes[n.down].up = node
+		}
+	}
+	m.nodes[header.left].right = column
+	m.nodes[header.right].left = column
+	m.covered[column] = false
+}
+
+// selectRow covers the columns of the option before the search, it returns false when one of them is already covered
+func (m *dlxMatrix) selectRow(row int) bool {
+	node := row
+	for {
+		if m.covered[m.nodes[node].column] {
+			return false
+		}
+		m.cover(m.nodes[node].column)
+		node = m.nodes[node].right
+		if node == row {
+			return true
+		}
+	}
+}
+
+// dlxSearch is Algorithm X over the matrix, choosing the primary column having the fewest options
+type dlxSearch struct {
+	ctx     context.Context
+	matrix  *dlxMatrix
+	values  [CellCount]Value
+	visit   func(Grid) bool
+	nodes   int
+	stopped bool
+	err     error
+}
+
+func (s *dlxSearch) search() {
+	if s.nodes%cancelCheckInterval == 0 {
+		if err := canceled(s.ctx); err != nil {
+			s.err = err
+			s.stopped = true
+			return
+		}
+	}
+	s.nodes++
+	m := s.matrix
+	if m.nodes[0].right == 0 {
+		s.stopped = !s.visit(Grid{Values: s.values})
+		return
+	}
+	column, size := 0, -1
+	for c := m.nodes[0].right; c != 0; c = m.nodes[c].right {
+		if size == -1 || m.sizes[c] < size {
+			column, size = c, m.sizes[c]
+			if size <= 1 {
+				break
+			}
+		}
+	}
+	if size == 0 {
+		return
+	}
+
+	m.cover(column)
+	for row := m.nodes[column].down; row != column && !s.stopped; row = m.nodes[row].down {
+		for node := m.nodes[row].right; node != row; node = m.nodes[node].right {
+			m.cover(m.nodes[node].column)
+		}
+		option := m.nodes[row].option
+		s.values[option/BoardSize] = Value(option%BoardSize + 1)
+		s.search()
+		s.values[option/BoardSize] = EmptyCellValue
+		for node := m.nodes[row].left; node != row; node = m.nodes[node].left {
+			m.uncover(m.nodes[node].column)
+		}
+	}
+	m.uncover(column)
+}
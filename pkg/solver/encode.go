@@ -0,0 +1,374 @@
+package solver
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ErrorType is the machine-readable type of a solve error
+type ErrorType string
+
+const (
+	ConflictErrorType          ErrorType = "conflict"
+	TooFewGivensErrorType      ErrorType = "too_few_givens"
+	InvalidValueErrorType      ErrorType = "invalid_value"
+	EmptyCandidatesErrorType   ErrorType = "empty_candidates"
+	NoSolutionErrorType        ErrorType = "no_solution"
+	MultipleSolutionsErrorType ErrorType = "multiple_solutions"
+	StalledErrorType           ErrorType = "stalled"
+	CanceledErrorType          ErrorType = "canceled"
+	ParseErrorType             ErrorType = "parse"
+	UnknownErrorType           ErrorType = "error"
+)
+
+// ResponseFormats keeps the names accepted by NewResponseWriter
+var ResponseFormats = []string{"text", "json", "ndjson", "csv"}
+
+// csvHeader keeps the columns written by the CSV writer
+var csvHeader = []string{
+	"initial", "solution", "solved", "difficulty", "clue_difficulty", "score", "hardest_strategy", "givens",
+	"duration_ns", "backtracking_used", "strategies", "error_type", "error_message",
+}
+
+// ErrorInfo is the machine-readable form of an error. UnitIndex, Line and Column are one based and the fields which
+// don't apply to the error type are left empty
+type ErrorInfo struct {
+	Type      ErrorType `json:"type"`
+	Message   string    `json:"message"`
+	Unit      UnitType  `json:"unit,omitempty"`
+	UnitIndex int       `json:"unit_index,omitempty"`
+	Value     int       `json:"value,omitempty"`
+	Cells     []string  `json:"cells,omitempty"`
+	Strategy  string    `json:"strategy,omitempty"`
+	Count     int       `json:"count,omitempty"`
+	Line      int       `json:"line,omitempty"`
+	Column    int       `json:"column,omitempty"`
+}
+
+// NewErrorInfo returns the machine-readable form of the given error, nil for a nil error
+func NewErrorInfo(err error) *ErrorInfo {
+	if err == nil {
+		return nil
+	}
+	info := &ErrorInfo{Type: UnknownErrorType, Message: err.Error()}
+	var (
+		conflict     *ConflictError
+		tooFew       *TooFewGivensError
+		invalidValue *InvalidValueError
+		empty        *EmptyCandidatesError
+		noSolution   *NoSolutionError
+		multiple     *MultipleSolutionsError
+		stalled      *StalledError
+		cancel       *CanceledError
+		diagnostic   *Diagnostic
+	)
+	switch {
+	case errors.As(err, &conflict):
+		info.Type = ConflictErrorType
+		info.Unit = conflict.Unit
+		info.UnitIndex = conflict.Index + 1
+		info.Value = int(conflict.Value)
+		info.Cells = RefStrings(conflict.Cells)
+	case errors.As(err, &tooFew):
+		info.Type = TooFewGivensErrorType
+		info.Count = tooFew.Givens
+	case errors.As(err, &invalidValue):
+		info.Type = InvalidValueErrorType
+		info.Value = int(invalidValue.Value)
+		info.Cells = RefStrings([]CellRef{invalidValue.Cell})
+	case errors.As(err, &empty):
+		info.Type = EmptyCandidatesErrorType
+		info.Cells = RefStrings([]CellRef{empty.Cell})
+		info.Strategy = empty.Strategy.String()
+	case errors.As(err, &noSolution):
+		info.Type = NoSolutionErrorType
+	case errors.As(err, &multiple):
+		info.Type = MultipleSolutionsErrorType
+		info.Count = multiple.Count
+	case errors.As(err, &stalled):
+		info.Type = StalledErrorType
+		info.Count = stalled.OpenCells
+	case errors.As(err, &cancel):
+		info.Type = CanceledErrorType
+	}
+	if errors.As(err, &diagnostic) {
+		info.Line = diagnostic.Line
+		info.Column = diagnostic.Column
+		if info.Type == UnknownErrorType {
+			info.Type = ParseErrorType
+		}
+	}
+	return info
+}
+
+// SolveRecord is the machine-readable form of a SolveResponse with the board states in the 81 character line
+// format and the duration in nanoseconds
+type SolveRecord struct {
+	Initial          string            `json:"initial"`
+	Solution         string            `json:"solution"`
+	Solved           bool              `json:"solved"`
+	Difficulty       string            `json:"difficulty"`
+	ClueDifficulty   string            `json:"clue_difficulty"`
+	Score            float64           `json:"score"`
+	HardestStrategy  string            `json:"hardest_strategy,omitempty"`
+	Givens           int               `json:"givens"`
+	DurationNs       int64             `json:"duration_ns"`
+	BackTrackingUsed bool              `json:"backtracking_used"`
+	Strategies       []string          `json:"strategies"`
+	Trace            []DeductionRecord `json:"trace,omitempty"`
+	Stall            *StallRecord      `json:"stall,omitempty"`
+	Error            *ErrorInfo        `json:"error,omitempty"`
+}
+
+// DeductionRecord is the machine-readable form of a Deduction with the cells in the rXcY notation
+type DeductionRecord struct {
+	Strategy     string              `json:"strategy"`
+	Pattern      []string            `json:"pattern,omitempty"`
+	Digits       []int               `json:"digits,omitempty"`
+	Placements   []PlacementRecord   `json:"placements,omitempty"`
+	Eliminations []EliminationRecord `json:"eliminations,omitempty"`
+	Fins         []string            `json:"fins,omitempty"`
+	Clusters     []ClusterRecord     `json:"clusters,omitempty"`
+}
+
+// PlacementRecord is the machine-readable form of a Placement
+type PlacementRecord struct {
+	Cell  string `json:"cell"`
+	Value int    `json:"value"`
+}
+
+// EliminationRecord is the machine-readable form of an Elimination
+type EliminationRecord struct {
+	Cell   string `json:"cell"`
+	Digits []int  `json:"digits"`
+}
+
+// ClusterRecord is the machine-readable form of a ColorCluster
+type ClusterRecord struct {
+	Colors [2][]string `json:"colors"`
+}
+
+// StallRecord is the machine-readable form of a Stall, the marks/candidates are kept in the pencil-mark layout
+type StallRecord struct {
+	Candidates      string   `json:"candidates"`
+	OpenCells       []string `json:"open_cells"`
+	StrategiesTried []string `json:"strategies_tried"`
+}
+
+// NewDeductionRecord returns the machine-readable form of the deduction
+func NewDeductionRecord(deduction Deduction) DeductionRecord {
+	record := DeductionRecord{
+		Strategy:     deduction.Strategy.String(),
+		Pattern:      RefStrings(deduction.Pattern),
+		Digits:       deduction.Digits.ToArray(),
+		Placements:   make([]PlacementRecord, 0, len(deduction.Placements)),
+		Eliminations: make([]EliminationRecord, 0, len(deduction.Eliminations)),
+		Fins:         RefStrings(deduction.Fins),
+		Clusters:     make([]ClusterRecord, 0, len(deduction.Clusters)),
+	}
+	for _, placement := range deduction.Placements {
+		record.Placements = append(record.Placements, PlacementRecord{Cell: placement.Cell.String(), Value: int(placement.Value)})
+	}
+	for _, elimination := range deduction.Eliminations {
+		record.Eliminations = append(record.Eliminations, EliminationRecord{Cell: elimination.Cell.String(), Digits: elimination.Marks.ToArray()})
+	}
+	for _, cluster := range deduction.Clusters {
+		record.Clusters = append(record.Clusters, ClusterRecord{Colors: [2][]string{RefStrings(cluster.Colors[0]), RefStrings(cluster.Colors[1])}})
+	}
+	return record
+}
+
+// newStallRecord returns the machine-readable form of the stall, nil for a nil stall
+func newStallRecord(stall *Stall) *StallRecord {
+	if stall == nil {
+		return nil
+	}
+	record := &StallRecord{
+		Candidates:      stall.Candidates,
+		OpenCells:       RefStrings(stall.OpenCells),
+		StrategiesTried: make([]string, 0, len(stall.StrategiesTried)),
+	}
+	for _, strategy := range stall.StrategiesTried {
+		record.StrategiesTried = append(record.StrategiesTried, strategy.String())
+	}
+	return record
+}
+
+// Record returns the machine-readable form of the response
+func (r *SolveResponse) Record() *SolveRecord {
+	record := &SolveRecord{
+		Initial:          r.InitialGrid,
+		Solution:         r.SolutionGrid,
+		Solved:           r.IsSolved,
+		Difficulty:       r.Difficulty,
+		ClueDifficulty:   r.ClueDifficulty,
+		Givens:           r.Givens,
+		DurationNs:       r.Elapsed.Nanoseconds(),
+		BackTrackingUsed: r.BackTrackingUsed,
+		Strategies:       append(make([]string, 0, len(r.StrategiesUsed)), r.StrategiesUsed...),
+		Trace:            make([]DeductionRecord, 0, len(r.SolveTrace)),
+		Stall:            newStallRecord(r.Stall),
+		Error:            NewErrorInfo(r.Error),
+	}
+	for _, deduction := range r.SolveTrace {
+		record.Trace = append(record.Trace, NewDeductionRecord(deduction))
+	}
+	if r.Rating != nil {
+		record.Score = r.Rating.Score
+		record.HardestStrategy = r.Rating.HardestStrategy.String()
+	}
+	return record
+}
+
+// MarshalJSON encodes the response as its SolveRecord, the solve trace and the stall included
+func (r *SolveResponse) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.Record())
+}
+
+// csvRow returns the record in the column order of csvHeader, the strategies are separated by ';'
+func (s *SolveRecord) csvRow() []string {
+	errorType, errorMessage := "", ""
+	if s.Error != nil {
+		errorType, errorMessage = string(s.Error.Type), s.Error.Message
+	}
+	return []string{
+		s.Initial,
+		s.Solution,
+		strconv.FormatBool(s.Solved),
+		s.Difficulty,
+		s.ClueDifficulty,
+		strconv.FormatFloat(s.Score, 'f', 2, 64),
+		s.HardestStrategy,
+		strconv.Itoa(s.Givens),
+		strconv.FormatInt(s.DurationNs, 10),
+		strconv.FormatBool(s.BackTrackingUsed),
+		strings.Join(s.Strategies, ";"),
+		errorType,
+		errorMessage,
+	}
+}
+
+// ResponseWriter writes the solve responses one by one, Flush completes the output after the last response
+type ResponseWriter interface {
+	Write(response *SolveResponse) error
+	Flush() error
+}
+
+// NewResponseWriter returns the writer of the given format name, one of ResponseFormats
+func NewResponseWriter(w io.Writer, format string) (ResponseWriter, error) {
+	switch strings.ToLower(format) {
+	case "text":
+		return NewTextWriter(w), nil
+	case "json":
+		return NewJSONWriter(w), nil
+	case "ndjson":
+		return NewNDJSONWriter(w), nil
+	case "csv":
+		return NewCSVWriter(w), nil
+	}
+	return nil, fmt.Errorf("unknown output format: %q", format)
+}
+
+type textWriter struct {
+	w io.Writer
+}
+
+// NewTextWriter returns a writer printing the responses with SolveResponse.Print
+func NewTextWriter(w io.Writer) ResponseWriter {
+	return &textWriter{w: w}
+}
+
+func (t *textWriter) Write(response *SolveResponse) error {
+	_, err := io.WriteString(t.w, response.Print()+"\n")
+	return err
+}
+
+func (t *textWriter) Flush() error {
+	return nil
+}
+
+type jsonWriter struct {
+	w     io.Writer
+	count int
+}
+
+// NewJSONWriter returns a writer of a JSON array with a record on each line, the array is closed by Flush
+func NewJSONWriter(w io.Writer) ResponseWriter {
+	return &jsonWriter{w: w}
+}
+
+func (j *jsonWriter) Write(response *SolveResponse) error {
+	data, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+	separator := ",\n  "
+	if j.count == 0 {
+		separator = "[\n  "
+	}
+	j.count++
+	_, err = io.WriteString(j.w, separator+string(data))
+	return err
+}
+
+func (j *jsonWriter) Flush() error {
+	closing := "\n]\n"
+	if j.count == 0 {
+		closing = "[]\n"
+	}
+	_, err := io.WriteString(j.w, closing)
+	return err
+}
+
+type ndjsonWriter struct {
+	encoder *json.Encoder
+}
+
+// NewNDJSONWriter returns a writer streaming a JSON record on each line
+func NewNDJSONWriter(w io.Writer) ResponseWriter {
+	return &ndjsonWriter{encoder: json.NewEncoder(w)}
+}
+
+func (n *ndjsonWriter) Write(response *SolveResponse) error {
+	return n.encoder.Encode(response)
+}
+
+func (n *ndjsonWriter) Flush() error {
+	return nil
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+	header bool
+}
+
+// NewCSVWriter returns a writer of a CSV row for each response, the header row is written before the first one
+func NewCSVWriter(w io.Writer) ResponseWriter {
+	return &csvWriter{writer: csv.NewWriter(w)}
+}
+
+func (c *csvWriter) Write(response *SolveResponse) error {
+	if !c.header {
+		if err := c.writer.Write(csvHeader); err != nil {
+			return err
+		}
+		c.header = true
+	}
+	return c.writer.Write(response.Record().csvRow())
+}
+
+func (c *csvWriter) Flush() error {
+	if !c.header {
+		if err := c.writer.Write(csvHeader); err != nil {
+			return err
+		}
+		c.header = true
+	}
+	c.writer.Flush()
+	return c.writer.Error()
+}
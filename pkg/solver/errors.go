@@ -0,0 +1,130 @@
+package solver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrAlreadySolved is returned by Hint when the board has no unsolved cells
+	ErrAlreadySolved = errors.New("board is already solved")
+	// ErrNoDeduction is returned by Hint when none of the strategies finds a deduction
+	ErrNoDeduction = errors.New("no logical deduction available")
+)
+
+// UnitType is the type of the unit a cell belongs to
+type UnitType string
+
+const (
+	RowUnit UnitType = "row"
+	ColUnit UnitType = "col"
+	BoxUnit UnitType = "box"
+)
+
+// ConflictError is returned when the same value appears more than once in a unit. Index is the zero based index of
+// the unit and Cells keeps the conflicting cells
+type ConflictError struct {
+	Unit  UnitType
+	Index int
+	Value Value
+	Cells []CellRef
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("invalid board; conflicting values: %d appears in %s %d at %s", e.Value, e.Unit, e.Index+1, joinRefs(e.Cells))
+}
+
+// InvalidValueError is returned when a given value is out of the 0-9 range
+type InvalidValueError struct {
+	Cell  CellRef
+	Value Value
+}
+
+func (e *InvalidValueError) Error() string {
+	return fmt.Sprintf("%d is not valid input at %s", e.Value, e.Cell)
+}
+
+// TooFewGivensError is returned when the board has fewer givens than needed for a unique solution
+type TooFewGivensError struct {
+	Givens  int
+	Minimum int
+}
+
+func (e *TooFewGivensError) Error() string {
+	return fmt.Sprintf("at least %d cells should be given to find out unique solution; current givens: %d", e.Minimum, e.Givens)
+}
+
+// EmptyCandidatesError is returned when an unsolved cell loses all of its marks/candidates. Strategy is the strategy
+// which emptied the cell, it is empty when the candidates are emptied by the solved peers
+type EmptyCandidatesError struct {
+	Cell     CellRef
+	Strategy StrategyName
+}
+
+func (e *EmptyCandidatesError) Error() string {
+	if e.Strategy == "" {
+		return fmt.Sprintf("invalid board; empty marks: cell: %s", e.Cell)
+	}
+	return fmt.Sprintf("invalid board: %s: empty marks: cell: %s", e.Strategy, e.Cell)
+}
+
+// NoSolutionError is returned when the board has no solution
+type NoSolutionError struct{}
+
+func (e *NoSolutionError) Error() string {
+	return "invalid board; no solution exists"
+}
+
+// MultipleSolutionsError is returned when a unique solution is required but the board has more than one. Count is
+// the number of solutions found before the search stopped
+type MultipleSolutionsError struct {
+	Count int
+}
+
+func (e *MultipleSolutionsError) Error() string {
+	return fmt.Sprintf("invalid board; multiple solutions exist: found at least %d", e.Count)
+}
+
+// StalledError is returned when the strategies stop making progress and backtracking is not allowed
+type StalledError struct {
+	OpenCells int
+	Tried     []StrategyName
+}
+
+func (e *StalledError) Error() string {
+	names := make([]string, 0, len(e.Tried))
+	for _, name := range e.Tried {
+		names = append(names, name.String())
+	}
+	return fmt.Sprintf("strategies stalled without backtracking; %d cells remain open after [%s]", e.OpenCells, strings.Join(names, ", "))
+}
+
+// CanceledError is returned when the context is done before the board is solved. Err keeps the context error
+type CanceledError struct {
+	Err error
+}
+
+func (e *CanceledError) Error() string {
+	return "solve canceled: " + e.Err.Error()
+}
+
+func (e *CanceledError) Unwrap() error {
+	return e.Err
+}
+
+// canceled returns a *CanceledError if the context is done
+func canceled(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return &CanceledError{Err: ctx.Err()}
+	default:
+		return nil
+	}
+}
+
+// emptyCandidates returns an *EmptyCandidatesError for the given cell emptied by the given strategy
+func emptyCandidates(cell *Cell, strategy StrategyName) error {
+	return &EmptyCandidatesError{Cell: refOf(cell), Strategy: strategy}
+}
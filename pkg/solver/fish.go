@@ -0,0 +1,282 @@
+package solver
+
+import "fmt"
+
+// fishStrategies names the basic fish of each size
+var fishStrategies = map[int]StrategyName{
+	2: XWingsStrategy,
+	3: SwordFishStrategy,
+	4: JellyfishStrategy,
+}
+
+// finnedFishStrategies names the finned fish of each size, the sashimi fish included
+var finnedFishStrategies = map[int]StrategyName{
+	2: FinnedXWingsStrategy,
+	3: FinnedSwordFishStrategy,
+	4: FinnedJellyfishStrategy,
+}
+
+// Fish is a fish of a digit. Its base lines, the rows or the cols, hold the digit only within as many cover lines, so
+// each cover line gets the digit in one of the base cells and loses it everywhere else. Base and Cover keep the line
+// indexes plus one, like IndexesBitmap.
+//
+// A finned fish has extra base cells outside the cover lines, the fins, all in one box. Either a fin holds the digit
+// or the fish does, so only the cells seeing every fin lose the digit. A sashimi fish is a finned fish which would be
+// degenerate without its fins, one of its base lines holds the digit in a single cell of the cover lines
+type Fish struct {
+	Base    CandidateSet
+	Cover   CandidateSet
+	Cells   []*Cell
+	Fins    []*Cell
+	Mark    CandidateSet
+	ByRows  bool
+	Sashimi bool
+}
+
+// Size returns the number of the base lines of the fish
+func (f *Fish) Size() int {
+	return f.Base.GetCardinality()
+}
+
+// Strategy returns the name of the fish, e.g. X Wings for a fish of size 2 and Finned X Wings when it has fins
+func (f *Fish) Strategy() StrategyName {
+	if len(f.Fins) > 0 {
+		return finnedFishStrategies[f.Size()]
+	}
+	return fishStrategies[f.Size()]
+}
+
+// Targets returns the cells of the cover lines outside the base lines which still hold the digit and see every fin
+func (f *Fish) Targets(b *Board) []*Cell {
+	targets := make([]*Cell, 0)
+	for _, index := range f.Cover.ToArray() {
+		for _, cell := range candidateCellsForMark(orthogonalLine(b, index-1, f.ByRows), f.Mark) {
+			if !f.Base.Contains(lineIndex(cell, f.ByRows)+1) && seesAll(cell, f.Fins) {
+				targets = append(targets, cell)
+			}
+		}
+	}
+	return targets
+}
+
+// Eliminate removes the digit from the targets of the fish
+func (f *Fish) Eliminate(b *Board) error {
+	snapshot := snapshotMarks(b.unsolvedCells())
+	for _, cell := range f.Targets(b) {
+		if err := eliminateMarkFromCell(cell, f.Mark, f.Strategy()); err != nil {
+			return err
+		}
+	}
+	record := b.recordDeduction
+	if len(f.Fins) > 0 {
+		record = func(deduction Deduction) {
+			deduction.Fins = refsOf(f.Fins)
+			b.recordDeduction(deduction)
+		}
+	}
+	snapshot.record(record, f.Strategy(), f.Cells, f.Mark)
+	return nil
+}
+
+// EliminateFish eliminates marks/candidates using the basic fish of the given size: X Wings for 2, Sword Fish for 3
+// and Jellyfish for 4
+func EliminateFish(b *Board, size int) error {
+	fishes, err := FindFish(b, size)
+	if err != nil {
+		return err
+	}
+	return eliminateFishes(b, fishes)
+}
+
+// EliminateFinnedFish eliminates marks/candidates using the finned and the sashimi fish of the given size
+func EliminateFinnedFish(b *Board, size int) error {
+	fishes, err := FindFinnedFish(b, size)
+	if err != nil {
+		return err
+	}
+	return eliminateFishes(b, fishes)
+}
+
+func eliminateFishes(b *Board, fishes []*Fish) error {
+	for _, fish := range fishes {
+		if eliminateErr := fish.Eliminate(b); eliminateErr != nil {
+			return eliminateErr
+		}
+	}
+	return nil
+}
+
+// FindFish returns the basic fish of the given size which eliminate a mark/candidate. Every digit is searched in
+// both orientations, the base lines are the lines holding the digit in two to size cells
+func FindFish(b *Board, size int) ([]*Fish, error) {
+	if _, ok := fishStrategies[size]; !ok {
+		return nil, fmt.Errorf("fish size should be 2 to 4, found %d", size)
+	}
+	fishes := make([]*Fish, 0)
+	for _, byRows := range []bool{true, false} {
+		for digit := 1; digit <= BoardSize; digit++ {
+			mark := CandidateSetOf(digit)
+			positions, lines := fishLines(b, mark, byRows, 2, size)
+			for _, base := range lineCombinations(lines, size) {
+				cells := make([]*Cell, 0, size*size)
+				for _, index := range base.ToArray() {
+					cells = append(cells, positions[index-1]...)
+				}
+				cover := IndexesBitmap(cells, byRows)
+				if cover.GetCardinality() != size {
+					continue
+				}
+				fish := &Fish{Base: base, Cover: cover, Cells: cells, Mark: mark, ByRows: byRows}
+				if len(fish.Targets(b)) > 0 {
+					fishes = append(fishes, fish)
+				}
+			}
+		}
+	}
+	return fishes, nil
+}
+
+// FindFinnedFish returns the finned and the sashimi fish of the given size which eliminate a mark/candidate. The base
+// lines may hold the digit outside the cover lines as long as these extra cells, the fins, fall into one box
+func FindFinnedFish(b *Board, size int) ([]*Fish, error) {
+	if _, ok := fishStrategies[size]; !ok {
+		return nil, fmt.Errorf("fish size should be 2 to 4, found %d", size)
+	}
+	fishes := make([]*Fish, 0)
+	for _, byRows := range []bool{true, false} {
+		for digit := 1; digit <= BoardSize; digit++ {
+			mark := CandidateSetOf(digit)
+			positions, lines := fishLines(b, mark, byRows, 1, size+BlockSize)
+			for _, base := range lineCombinations(lines, size) {
+				cells := make([]*Cell, 0, size*BoardSize)
+				for _, index := range base.ToArray() {
+					cells = append(cells, positions[index-1]...)
+				}
+				indexes := IndexesBitmap(cells, byRows)
+				if indexes.GetCardinality() <= size {
+					continue
+				}
+				for _, cover := range lineCombinations(indexes.ToArray(), size) {
+					if fish, ok := finnedFish(base, cover, cells, mark, byRows); ok && len(fish.Targets(b)) > 0 {
+						fishes = append(fishes, fish)
+					}
+				}
+			}
+		}
+	}
+	return fishes, nil
+}
+
+// finnedFish splits the base cells into the fish cells within the cover lines and the fins outside them, false when
+// the fins don't share a box or a base line has no cell within the cover lines
+func finnedFish(base CandidateSet, cover CandidateSet, cells []*Cell, mark CandidateSet, byRows bool) (*Fish, bool) {
+	var counts [BoardSize]int
+	fins, box := 0, -1
+	for _, cell := range cells {
+		if cover.Contains(orthogonalIndex(cell, byRows) + 1) {
+			counts[lineIndex(cell, byRows)]++
+			continue
+		}
+		if box == -1 {
+			box = boxIndex(cell.Row, cell.Col)
+		} else if boxIndex(cell.Row, cell.Col) != box {
+			return nil, false
+		}
+		fins++
+	}
+	fish := &Fish{Base: base, Cover: cover, Mark: mark, ByRows: byRows}
+	for _, index := range base.ToArray() {
+		switch counts[index-1] {
+		case 0:
+			return nil, false
+		case 1:
+			fish.Sashimi = true
+		}
+	}
+	fish.Cells = make([]*Cell, 0, len(cells)-fins)
+	fish.Fins = make([]*Cell, 0, fins)
+	for _, cell := range cells {
+		if cover.Contains(orthogonalIndex(cell, byRows) + 1) {
+			fish.Cells = append(fish.Cells, cell)
+		} else {
+			fish.Fins = append(fish.Fins, cell)
+		}
+	}
+	return fish, true
+}
+
+// fishLines returns the cells of each line holding the digit and the indexes plus one of the lines holding it in
+// fewest to most cells
+func fishLines(b *Board, mark CandidateSet, byRows bool, fewest int, most int) ([BoardSize][]*Cell, []int) {
+	var positions [BoardSize][]*Cell
+	lines := make([]int, 0, BoardSize)
+	for i := 0; i < BoardSize; i++ {
+		positions[i] = candidateCellsForMark(line(b, i, byRows), mark)
+		if count := len(positions[i]); count >= fewest && count <= most {
+			lines = append(lines, i+1)
+		}
+	}
+	return positions, lines
+}
+
+// seesAll reports whether the cell shares a unit with each of the given cells
+func seesAll(cell *Cell, cells []*Cell) bool {
+	for _, other := range cells {
+		if !cell.Sees(other) {
+			return false
+		}
+	}
+	return true
+}
+
+// lineCombinations returns the combinations of the given size of the line indexes
+func lineCombinations(lines []int, size int) []CandidateSet {
+	switch size {
+	case 2:
+		return BitmapPairs(lines)
+	case 3:
+		return BitmapTriplets(lines)
+	case 4:
+		return BitmapQuads(lines)
+	}
+	return nil
+}
+
+func line(b *Board, index int, byRows bool) []*Cell {
+	if byRows {
+		return b.row(index)
+	}
+	return b.col(index)
+}
+
+func orthogonalLine(b *Board, index int, byRows bool) []*Cell {
+	if byRows {
+		return b.col(index)
+	}
+	return b.row(index)
+}
+
+// lineIndex returns the index of the base line of the cell, its row when the fish is searched by rows
+func lineIndex(cell *Cell, byRows bool) int {
+	if byRows {
+		return cell.Row
+	}
+	return cell.Col
+}
+
+// orthogonalIndex returns the index of the cover line of the cell, its col when the fish is searched by rows
+func orthogonalIndex(cell *Cell, byRows bool) int {
+	return lineIndex(cell, !byRows)
+}
+
+func IndexesBitmap(cells []*Cell, byRows bool) CandidateSet {
+	var indexes CandidateSet
+	for _, cell := range cells {
+		if byRows {
+			indexes = indexes.Add(cell.Col + 1)
+		} else {
+			indexes = indexes.Add(cell.Row + 1)
+		}
+	}
+	return indexes
+}
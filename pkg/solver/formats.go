@@ -0,0 +1,425 @@
+package solver
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// Format is a puzzle file format
+type Format int
+
+const (
+	// AutoFormat detects the format from the content while reading
+	AutoFormat Format = iota
+	// LineFormat is one 81 character board per line with '.' or '0' for the empty cells
+	LineFormat
+	// GridFormat is the multi-line 9x9 grid text with optional '|' separators and border lines, as written by getState
+	GridFormat
+	// SDKFormat is the SadMan Software format with '#' metadata lines and nine rows of nine cells
+	SDKFormat
+	// SDMFormat is the SadMan collection format with one 81 digit board per line
+	SDMFormat
+	// SSFormat is the Simple Sudoku format with '|' separated boxes and border lines
+	SSFormat
+	// JSONFormat is a JSON object, or an array of objects, with the givens and the metadata of the puzzles
+	JSONFormat
+)
+
+var Formats = map[Format]string{
+	AutoFormat: "auto",
+	LineFormat: "line",
+	GridFormat: "grid",
+	SDKFormat:  "sdk",
+	SDMFormat:  "sdm",
+	SSFormat:   "ss",
+	JSONFormat: "json",
+}
+
+// formatExtensions keeps the formats having a well known file extension
+var formatExtensions = map[string]Format{
+	".sdk":  SDKFormat,
+	".sdm":  SDMFormat,
+	".ss":   SSFormat,
+	".json": JSONFormat,
+}
+
+func (f Format) String() string {
+	return Formats[f]
+}
+
+// ParseFormat returns the format with the given name, e.g. "sdk"
+func ParseFormat(name string) (Format, error) {
+	for format, formatName := range Formats {
+		if strings.EqualFold(name, formatName) {
+			return format, nil
+		}
+	}
+	return AutoFormat, fmt.Errorf("unknown format: %q", name)
+}
+
+// FormatFromPath returns the format of the file from its extension
+func FormatFromPath(path string) (Format, bool) {
+	format, ok := formatExtensions[strings.ToLower(filepath.Ext(path))]
+	return format, ok
+}
+
+// Puzzle is a puzzle read from or written to a puzzle file with its metadata
+type Puzzle struct {
+	Name    string
+	Author  string
+	Source  string
+	Comment string
+	Givens  [BoardSize][BoardSize]Value
+}
+
+// jsonPuzzle is the JSON schema of a puzzle, the givens are written in the 81 character line format
+type jsonPuzzle struct {
+	Name    string `json:"name,omitempty"`
+	Author  string `json:"author,omitempty"`
+	Source  string `json:"source,omitempty"`
+	Comment string `json:"comment,omitempty"`
+	Givens  string `json:"givens"`
+}
+
+// NewPuzzle returns a puzzle with the givens of the board
+func NewPuzzle(board *Board) *Puzzle {
+	return &Puzzle{Givens: board.Givens()}
+}
+
+// Board returns a new board with the givens of the puzzle
+func (p *Puzzle) Board() (*Board, error) {
+	return NewBoard(p.Givens)
+}
+
+func (p *Puzzle) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonPuzzle{
+		Name:    p.Name,
+		Author:  p.Author,
+		Source:  p.Source,
+		Comment: p.Comment,
+		Givens:  GridString(p.Givens),
+	})
+}
+
+func (p *Puzzle) UnmarshalJSON(data []byte) error {
+	var decoded jsonPuzzle
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	givens, diagnostic := parseGivens(decoded.Givens)
+	if diagnostic != nil {
+		return fmt.Errorf("givens: %s", diagnostic.Reason)
+	}
+	*p = Puzzle{
+		Name:    decoded.Name,
+		Author:  decoded.Author,
+		Source:  decoded.Source,
+		Comment: decoded.Comment,
+		Givens:  givens,
+	}
+	return nil
+}
+
+// GridString returns the values in the 81 character line format with '.' for the empty cells
+func GridString(values [BoardSize][BoardSize]Value) string {
+	return valuesString(values, '.')
+}
+
+func valuesString(values [BoardSize][BoardSize]Value, empty byte) string {
+	var builder strings.Builder
+	builder.Grow(BoardSize * BoardSize)
+	for i := 0; i < BoardSize; i++ {
+		for j := 0; j < BoardSize; j++ {
+			builder.WriteByte(valueChar(values[i][j], empty))
+		}
+	}
+	return builder.String()
+}
+
+func valueChar(value Value, empty byte) byte {
+	if value == EmptyCellValue {
+		return empty
+	}
+	return byte('0' + value)
+}
+
+// DetectFormat returns the format of the given content
+func DetectFormat(data []byte) Format {
+	text := strings.TrimSpace(string(data))
+	switch {
+	case strings.HasPrefix(text, "#"), strings.HasPrefix(strings.ToLower(text), "[puzzle]"):
+		return SDKFormat
+	case strings.HasPrefix(text, "{"), strings.HasPrefix(text, "["):
+		return JSONFormat
+	}
+	first, _, _ := strings.Cut(text, "\n")
+	first = strings.TrimSpace(first)
+	if len(first) >= BoardSize*BoardSize && !strings.ContainsAny(first, "|+- \t") {
+		return LineFormat
+	}
+	if len(first) == BoardSize && !strings.ContainsAny(first, "|+-*") {
+		return SDKFormat
+	}
+	for _, line := range strings.Split(text, "\n") {
+		if strings.Contains(line, "|") && strings.ContainsAny(line, "123456789._") {
+			if strings.Contains(strings.TrimSpace(line), " ") {
+				return GridFormat
+			}
+			return SSFormat
+		}
+	}
+	return GridFormat
+}
+
+// ReadPuzzles reads the puzzles in the given format, the format is detected from the content for AutoFormat. The
+// errors in the content are returned as a *Diagnostic
+func ReadPuzzles(r io.Reader, format Format) ([]*Puzzle, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if format == AutoFormat {
+		format = DetectFormat(data)
+	}
+	switch format {
+	case LineFormat, SDMFormat:
+		return readLinePuzzles(data)
+	case GridFormat, SDKFormat, SSFormat:
+		return readGridPuzzles(data)
+	case JSONFormat:
+		return readJSONPuzzles(data)
+	}
+	return nil, fmt.Errorf("unknown format: %d", format)
+}
+
+func readLinePuzzles(data []byte) ([]*Puzzle, error) {
+	puzzles := make([]*Puzzle, 0)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		givens, diagnostic := parseGivens(text)
+		if diagnostic != nil {
+			diagnostic.Line = line
+			return nil, diagnostic
+		}
+		puzzles = append(puzzles, &Puzzle{Givens: givens})
+	}
+	return puzzles, scanner.Err()
+}
+
+// readGridPuzzles reads the multi-line formats, every nine rows make a puzzle. The border lines are ignored, the
+// SadMan '#' lines set the metadata of the next puzzle and only the [Puzzle] section of a SadMan file is read
+func readGridPuzzles(data []byte) ([]*Puzzle, error) {
+	puzzles := make([]*Puzzle, 0)
+	current := &Puzzle{}
+	row := 0
+	section := ""
+	lines := strings.Split(string(data), "\n")
+	for index, line := range lines {
+		text := strings.TrimSpace(line)
+		switch {
+		case text == "", text[0] == '!':
+			continue
+		case text[0] == '#':
+			readSDKHeader(current, text)
+			continue
+		case text[0] == '[':
+			section = strings.ToLower(text)
+			continue
+		case section != "" && section != "[puzzle]":
+			continue
+		case isGridBorder(text):
+			continue
+		}
+		cells, diagnostic := gridRowValues(text)
+		if diagnostic != nil {
+			diagnostic.Line = index + 1
+			return nil, diagnostic
+		}
+		current.Givens[row] = cells
+		row++
+		if row == BoardSize {
+			puzzles = append(puzzles, current)
+			current = &Puzzle{}
+			row = 0
+		}
+	}
+	if row != 0 {
+		return nil, &Diagnostic{Line: len(lines), Reason: fmt.Sprintf("grid has %d rows, want %d", row, BoardSize)}
+	}
+	return puzzles, nil
+}
+
+// readSDKHeader sets the metadata of the puzzle from a SadMan header line, #D is the description which is taken
+// as the name of the puzzle
+func readSDKHeader(puzzle *Puzzle, text string) {
+	if len(text) < 2 {
+		return
+	}
+	value := strings.TrimSpace(text[2:])
+	switch text[1] {
+	case 'D':
+		puzzle.Name = value
+	case 'A':
+		puzzle.Author = value
+	case 'S':
+		puzzle.Source = value
+	case 'C':
+		puzzle.Comment = value
+	}
+}
+
+// isGridBorder reports whether the line is a border line such as "*-----------*", "------+-------+------" or the
+// "*_______*" borders of getState
+func isGridBorder(text string) bool {
+	return strings.ContainsAny(text, "-*=") && !strings.ContainsAny(text, "123456789")
+}
+
+// gridRowValues returns the values of a grid row, '.', '0' and '_' are the empty cells and the separators are ignored
+func gridRowValues(text string) ([BoardSize]Value, *Diagnostic) {
+	var values [BoardSize]Value
+	count := 0
+	for index := 0; index < len(text); index++ {
+		char := text[index]
+		if strings.IndexByte(" \t|+:", char) >= 0 {
+			continue
+		}
+		value, ok := HexMap[char]
+		if char == '_' {
+			value, ok = EmptyCellValue, true
+		}
+		if !ok {
+			return values, &Diagnostic{Column: index + 1, Reason: fmt.Sprintf("invalid character %q", char)}
+		}
+		if count == BoardSize {
+			return values, &Diagnostic{Column: index + 1, Reason: fmt.Sprintf("grid row has more than %d cells", BoardSize)}
+		}
+		values[count] = value
+		count++
+	}
+	if count != BoardSize {
+		return values, &Diagnostic{Reason: fmt.Sprintf("grid row has %d cells, want %d", count, BoardSize)}
+	}
+	return values, nil
+}
+
+func readJSONPuzzles(data []byte) ([]*Puzzle, error) {
+	trimmed := bytes.TrimSpace(data)
+	if bytes.HasPrefix(trimmed, []byte("[")) {
+		puzzles := make([]*Puzzle, 0)
+		if err := json.Unmarshal(trimmed, &puzzles); err != nil {
+			return nil, err
+		}
+		return puzzles, nil
+	}
+	puzzle := &Puzzle{}
+	if err := json.Unmarshal(trimmed, puzzle); err != nil {
+		return nil, err
+	}
+	return []*Puzzle{puzzle}, nil
+}
+
+// WritePuzzles writes the puzzles in the given format. The single puzzle formats write the puzzles one after
+// another separated by a blank line, JSON is written as an object for a single puzzle and as an array otherwise
+func WritePuzzles(w io.Writer, format Format, puzzles []*Puzzle) error {
+	var builder strings.Builder
+	switch format {
+	case LineFormat, SDMFormat:
+		empty := byte('.')
+		if format == SDMFormat {
+			empty = '0'
+		}
+		for _, puzzle := range puzzles {
+			builder.WriteString(valuesString(puzzle.Givens, empty))
+			builder.WriteByte(EOL)
+		}
+	case GridFormat, SDKFormat, SSFormat:
+		for i, puzzle := range puzzles {
+			if i > 0 {
+				builder.WriteByte(EOL)
+			}
+			writeGridPuzzle(&builder, format, puzzle)
+		}
+	case JSONFormat:
+		var value any = puzzles
+		if len(puzzles) == 1 {
+			value = puzzles[0]
+		}
+		data, err := json.MarshalIndent(value, "", "  ")
+		if err != nil {
+			return err
+		}
+		builder.Write(data)
+		builder.WriteByte(EOL)
+	default:
+		return fmt.Errorf("format %q can't be written", format)
+	}
+	_, err := io.WriteString(w, builder.String())
+	return err
+}
+
+func writeGridPuzzle(builder *strings.Builder, format Format, puzzle *Puzzle) {
+	switch format {
+	case SDKFormat:
+		for _, header := range []struct {
+			code  byte
+			value string
+		}{
+			{code: 'D', value: puzzle.Name},
+			{code: 'A', value: puzzle.Author},
+			{code: 'S', value: puzzle.Source},
+			{code: 'C', value: puzzle.Comment},
+		} {
+			if header.value != "" {
+				builder.WriteString(fmt.Sprintf("#%c %s\n", header.code, header.value))
+			}
+		}
+		for i := 0; i < BoardSize; i++ {
+			for j := 0; j < BoardSize; j++ {
+				builder.WriteByte(valueChar(puzzle.Givens[i][j], '.'))
+			}
+			builder.WriteByte(EOL)
+		}
+	case SSFormat:
+		builder.WriteString("*-----------*\n")
+		for i := 0; i < BoardSize; i++ {
+			if i > 0 && i%BlockSize == 0 {
+				builder.WriteString("|---+---+---|\n")
+			}
+			for j := 0; j < BoardSize; j++ {
+				if j%BlockSize == 0 {
+					builder.WriteByte('|')
+				}
+				builder.WriteByte(valueChar(puzzle.Givens[i][j], '.'))
+			}
+			builder.WriteString("|\n")
+		}
+		builder.WriteString("*-----------*\n")
+	default:
+		for i := 0; i < BoardSize; i++ {
+			if i > 0 && i%BlockSize == 0 {
+				builder.WriteString("------+-------+------\n")
+			}
+			for j := 0; j < BoardSize; j++ {
+				if j > 0 {
+					builder.WriteByte(' ')
+					if j%BlockSize == 0 {
+						builder.WriteString("| ")
+					}
+				}
+				builder.WriteByte(valueChar(puzzle.Givens[i][j], '.'))
+			}
+			builder.WriteByte(EOL)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package solver
+
+// Grid is the value form of the board state, the values and the marks/candidates of the cells indexed by their id
+// (row*BoardSize + col). Copying a Grid copies the whole state without any allocation, so the searches work on
+// Grid copies instead of the cells. The logical strategies don't use it, they work on the cells of a Board
+type Grid struct {
+	Values [CellCount]Value
+	Marks  [CellCount]CandidateSet
+}
+
+// GridOf returns the grid of the given values without any marks/candidates
+func GridOf(values [BoardSize][BoardSize]Value) Grid {
+	var grid Grid
+	for i := 0; i < BoardSize; i++ {
+		for j := 0; j < BoardSize; j++ {
+			grid.Values[cellID(i, j)] = values[i][j]
+		}
+	}
+	return grid
+}
+
+// gridOfData returns the grid of the given cells
+func gridOfData(data [BoardSize][BoardSize]*Cell) Grid {
+	var grid Grid
+	for i := 0; i < BoardSize; i++ {
+		for j := 0; j < BoardSize; j++ {
+			id := cellID(i, j)
+			grid.Values[id] = data[i][j].Value
+			grid.Marks[id] = data[i][j].Marks
+		}
+	}
+	return grid
+}
+
+// Grid returns the current state of the board as a Grid
+func (b *Board) Grid() Grid {
+	var grid Grid
+	for id := range b.cells {
+		grid.Values[id] = b.cells[id].Value
+		grid.Marks[id] = b.cells[id].Marks
+	}
+	return grid
+}
+
+// Matrix returns the values of the grid by row and col
+func (g *Grid) Matrix() [BoardSize][BoardSize]Value {
+	var values [BoardSize][BoardSize]Value
+	for id, value := range g.Values {
+		values[id/BoardSize][id%BoardSize] = value
+	}
+	return values
+}
+
+// Candidates returns the digits which none of the peers of the cell holds
+func (g *Grid) Candidates(id int) CandidateSet {
+	var used CandidateSet
+	for _, peer := range peerIDs[id] {
+		used |= 1 << g.Values[peer]
+	}
+	return Digits &^ used
+}
+
+// IsValid reports whether the given value can be put in the cell without repeating a peer value
+func (g *Grid) IsValid(id int, value Value) bool {
+	for _, peer := range peerIDs[id] {
+		if g.Values[peer] == value {
+			return false
+		}
+	}
+	return true
+}
+
+// Conflict returns a *ConflictError for the first unit containing the same value more than once. The row and the
+// col of each index are checked before the next index, then the boxes
+func (g *Grid) Conflict() *ConflictError {
+	for i := 0; i < BoardSize; i++ {
+		if conflict := g.unitConflict(i); conflict != nil {
+			return conflict
+		}
+		if conflict := g.unitConflict(BoardSize + i); conflict != nil {
+			return conflict
+		}
+	}
+	for i := 2 * BoardSize; i < UnitCount; i++ {
+		if conflict := g.unitConflict(i); conflict != nil {
+			return conflict
+		}
+	}
+	return nil
+}
+
+func (g *Grid) unitConflict(unit int) *ConflictError {
+	// The ids are kept one based so zero means not seen
+	var seen [BoardSize + 1]int
+	for _, id := range unitIDs[unit] {
+		value := g.Values[id]
+		if value == EmptyCellValue || value > Value(BoardSize) {
+			continue
+		}
+		if first := seen[value]; first != 0 {
+			unitType, index := unitOf(unit)
+			return &ConflictError{
+				Unit:  unitType,
+				Index: index,
+				Value: value,
+				Cells: []CellRef{refOfID(first - 1), refOfID(id)},
+			}
+		}
+		seen[value] = id + 1
+	}
+	return nil
+}
+
+// unitOf returns the type and the index within its type of the unit at the given index of unitIDs
+func unitOf(unit int) (UnitType, int) {
+	switch {
+	case unit < BoardSize:
+		return RowUnit, unit
+	case unit < 2*BoardSize:
+		return ColUnit, unit - BoardSize
+	}
+	return BoxUnit, unit - 2*BoardSize
+}
+
+func refOfID(id int) CellRef {
+	return CellRef{Row: id / BoardSize, Col: id % BoardSize}
+}
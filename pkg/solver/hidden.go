@@ -1,18 +1,20 @@
 package solver
 
-import (
-	"fmt"
-)
-
 func EliminateHiddenSingles(units [][]*Cell) error {
+	return eliminateHiddenSingles(units, nil)
+}
+
+func eliminateHiddenSingles(units [][]*Cell, record recordFunc) error {
 	for _, unit := range units {
 		unsolved := UnSolvedCells(unit)
 		found, single, bitmap := HiddenSingles(unsolved)
 		if found {
+			snapshot := snapshotMarks([]*Cell{single})
 			single.Marks = single.Marks.And(bitmap)
 			if single.Marks.IsEmpty() {
-				return fmt.Errorf("invalid board: HS: empty marks: cell: %+v", single)
+				return emptyCandidates(single, HiddenSingleStrategy)
 			}
+			snapshot.record(record, HiddenSingleStrategy, []*Cell{single}, bitmap)
 		}
 	}
 	return nil
@@ -41,16 +43,22 @@ func IsHiddenSingle(single *Cell, unit []*Cell) (bool, CandidateSet) {
 // EliminateHiddenPairs eliminates the marks from the pairs which has exactly and only the same two candidates all
 // over the unit. The other candidates could be removed safely from the pairs
 func EliminateHiddenPairs(units [][]*Cell) error {
+	return eliminateHiddenPairs(units, nil)
+}
+
+func eliminateHiddenPairs(units [][]*Cell, record recordFunc) error {
 	for _, unit := range units {
 		unsolved := UnSolvedCells(unit)
 		found, pair, bitmap := HiddenPairs(unsolved)
 		if found {
+			snapshot := snapshotMarks(pair)
 			for _, cell := range pair {
 				cell.Marks = cell.Marks.And(bitmap)
 				if cell.Marks.IsEmpty() {
-					return fmt.Errorf("invalid board: HP: empty marks: cell: %+v", cell)
+					return emptyCandidates(cell, HiddenPairsStrategy)
 				}
 			}
+			snapshot.record(record, HiddenPairsStrategy, pair, bitmap)
 		}
 	}
 	return nil
@@ -90,16 +98,22 @@ func IsHiddenPair(pair []*Cell, unit []*Cell) (bool, CandidateSet) {
 // EliminateHiddenTriplets eliminates the marks from the pairs which has exactly and only the same two candidates all
 // over the unit. The other candidates could be removed safely from the pairs
 func EliminateHiddenTriplets(units [][]*Cell) error {
+	return eliminateHiddenTriplets(units, nil)
+}
+
+func eliminateHiddenTriplets(units [][]*Cell, record recordFunc) error {
 	for _, unit := range units {
 		unsolved := UnSolvedCells(unit)
 		found, pair, bitmap := HiddenTriplets(unsolved)
 		if found {
+			snapshot := snapshotMarks(pair)
 			for _, cell := range pair {
 				cell.Marks = cell.Marks.And(bitmap)
 				if cell.Marks.IsEmpty() {
-					return fmt.Errorf("invalid board: HT: empty marks: cell: %+v", cell)
+					return emptyCandidates(cell, HiddenTripletsStrategy)
 				}
 			}
+			snapshot.record(record, HiddenTripletsStrategy, pair, bitmap)
 		}
 	}
 	return nil
@@ -137,15 +151,21 @@ func IsHiddenTriplet(triplet []*Cell, unit []*Cell) (bool, CandidateSet) {
 }
 
 func EliminateHiddenQuads(units [][]*Cell) error {
+	return eliminateHiddenQuads(units, nil)
+}
+
+func eliminateHiddenQuads(units [][]*Cell, record recordFunc) error {
 	for _, unit := range units {
 		found, quad, bitmap := HiddenQuads(UnSolvedCells(unit))
 		if found {
+			snapshot := snapshotMarks(quad)
 			for _, cell := range quad {
 				cell.Marks = cell.Marks.And(bitmap)
 				if cell.Marks.IsEmpty() {
-					return fmt.Errorf("invalid board: HQ: empty marks: cell: %+v", cell)
+					return emptyCandidates(cell, HiddenQuadsStrategy)
 				}
 			}
+			snapshot.record(record, HiddenQuadsStrategy, quad, bitmap)
 		}
 	}
 	return nil
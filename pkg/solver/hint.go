@@ -0,0 +1,229 @@
+package solver
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// hintRanks orders the strategies from the easiest to the hardest one for a human solver
+var hintRanks = map[StrategyName]int{
+	NakedSingleStrategy:      0,
+	HiddenSingleStrategy:     1,
+	LockedCandidatesStrategy: 2,
+	NakedPairsStrategy:       3,
+	HiddenPairsStrategy:      4,
+	NakedTriplesStrategy:     5,
+	HiddenTripletsStrategy:   6,
+	NakedQuadsStrategy:       7,
+	HiddenQuadsStrategy:      8,
+	XWingsStrategy:           9,
+	SkyscraperStrategy:       10,
+	TwoStringKiteStrategy:    11,
+	EmptyRectangleStrategy:   12,
+	XYWingsStrategy:          13,
+	WWingsStrategy:           14,
+	ColoringStrategy:         15,
+	XYZWingsStrategy:         16,
+	SwordFishStrategy:        17,
+	XYChainsStrategy:         18,
+	JellyfishStrategy:        19,
+	FinnedXWingsStrategy:     20,
+	FinnedSwordFishStrategy:  21,
+	FinnedJellyfishStrategy:  22,
+}
+
+// Hint is a single deduction which could be applied next with the cells it changes and a plain-English explanation
+type Hint struct {
+	Deduction
+	Targets     []CellRef
+	Explanation string
+}
+
+// Hint returns the easiest deduction available on the current state of the board without changing the board
+func (b *Board) Hint() (*Hint, error) {
+	return b.hint(orderedStrategies)
+}
+
+func (b *Board) hint(strategies []Strategy) (*Hint, error) {
+	clone := b.clone()
+	if err := clone.validateForSolve(); err != nil {
+		return nil, err
+	}
+	if err := clone.initializeCandidates(); err != nil {
+		return nil, err
+	}
+	if clone.isSolved() {
+		return nil, ErrAlreadySolved
+	}
+
+	if deduction, ok := clone.nakedSingle(); ok {
+		return newHint(deduction), nil
+	}
+
+	for _, strategy := range hintStrategies(strategies) {
+		if _, err := clone.applyStrategy(strategy); err != nil {
+			return nil, err
+		}
+		if len(clone.trace) > 0 {
+			return newHint(clone.trace[0]), nil
+		}
+	}
+	return nil, ErrNoDeduction
+}
+
+// clone returns a deep copy of the board so the deductions can be searched without changing the board
+func (b *Board) clone() *Board {
+	clone := &Board{
+		input:          b.input,
+		initialState:   b.initialState,
+		difficulty:     b.difficulty,
+		givens:         b.givens,
+		backTrackUsed:  b.backTrackUsed,
+		strategiesUsed: slices.Clone(b.strategiesUsed),
+		trace:          make([]Deduction, 0),
+		keepMarks:      b.keepMarks,
+	}
+	grid := b.Grid()
+	clone.setGrid(&grid)
+	return clone
+}
+
+// nakedSingle returns the placement of the first unsolved cell having exactly one mark/candidate
+func (b *Board) nakedSingle() (Deduction, bool) {
+	for _, cell := range b.unsolvedCells() {
+		value, ok := cell.Marks.First()
+		if !ok || cell.MarksLength() != 1 {
+			continue
+		}
+		return Deduction{
+			Strategy:   NakedSingleStrategy,
+			Pattern:    []CellRef{refOf(cell)},
+			Digits:     cell.Marks,
+			Placements: []Placement{{Cell: refOf(cell), Value: value}},
+		}, true
+	}
+	return Deduction{}, false
+}
+
+// hintStrategies returns the given strategies sorted from the easiest to the hardest one
+func hintStrategies(strategies []Strategy) []Strategy {
+	sorted := slices.Clone(strategies)
+	slices.SortStableFunc(sorted, func(a, b Strategy) int {
+		return hintRank(a.Name()) - hintRank(b.Name())
+	})
+	return sorted
+}
+
+func hintRank(name StrategyName) int {
+	if rank, ok := hintRanks[name]; ok {
+		return rank
+	}
+	return len(hintRanks)
+}
+
+func newHint(deduction Deduction) *Hint {
+	return &Hint{
+		Deduction:   deduction,
+		Targets:     deduction.Targets(),
+		Explanation: deduction.Explain(),
+	}
+}
+
+// Targets returns the cells changed by the deduction
+func (d Deduction) Targets() []CellRef {
+	targets := make([]CellRef, 0, len(d.Placements)+len(d.Eliminations))
+	for _, placement := range d.Placements {
+		targets = append(targets, placement.Cell)
+	}
+	for _, elimination := range d.Eliminations {
+		targets = append(targets, elimination.Cell)
+	}
+	return targets
+}
+
+// Explain returns a plain-English explanation of the deduction
+func (d Deduction) Explain() string {
+	pattern := joinRefs(d.Pattern)
+	eliminations := d.eliminationsText()
+	switch d.Strategy {
+	case NakedSingleStrategy:
+		return fmt.Sprintf("%s has a single candidate %s left, so it is placed there.", pattern, digitsText(d.Digits))
+	case HiddenSingleStrategy:
+		return fmt.Sprintf("%s can only go in %s within one of its units, so %s.", digitsText(d.Digits), pattern, eliminations)
+	case NakedPairsStrategy, NakedTriplesStrategy, NakedQuadsStrategy:
+		return fmt.Sprintf("%s contain only the candidates %s between them, so these digits cannot appear anywhere else in their unit: %s.", pattern, digitsText(d.Digits), eliminations)
+	case HiddenPairsStrategy, HiddenTripletsStrategy, HiddenQuadsStrategy:
+		return fmt.Sprintf("The candidates %s appear only in %s within their unit, so these cells cannot hold any other digit: %s.", digitsText(d.Digits), pattern, eliminations)
+	case LockedCandidatesStrategy:
+		return fmt.Sprintf("Within a unit %s is confined to %s, which also share another unit, so %s.", digitsText(d.Digits), pattern, eliminations)
+	case XWingsStrategy, SwordFishStrategy, JellyfishStrategy:
+		return fmt.Sprintf("%s forms a %s on %s; one of these cells must hold it in every cover line, so %s.", digitsText(d.Digits), d.Strategy, pattern, eliminations)
+	case FinnedXWingsStrategy, FinnedSwordFishStrategy, FinnedJellyfishStrategy:
+		return fmt.Sprintf("%s forms a %s on %s with the fins %s; either a fin or the fish holds it, so %s.", digitsText(d.Digits), d.Strategy, pattern, joinRefs(d.Fins), eliminations)
+	case SkyscraperStrategy, TwoStringKiteStrategy, EmptyRectangleStrategy:
+		return fmt.Sprintf("The strong links of %s on %s form a %s; one of its ends must hold the digit, so %s.", digitsText(d.Digits), pattern, d.Strategy, eliminations)
+	case ColoringStrategy:
+		return fmt.Sprintf("The conjugate pairs of %s color %s; one color of each cluster holds it in every cell, so %s.", digitsText(d.Digits), clustersText(d.Clusters), eliminations)
+	case XYWingsStrategy, XYZWingsStrategy:
+		return fmt.Sprintf("%s form a %s over %s; every cell seeing all of its wings loses the shared digit, so %s.", pattern, d.Strategy, digitsText(d.Digits), eliminations)
+	case WWingsStrategy:
+		return fmt.Sprintf("%s form a W Wing; the strong link in the middle makes one of the wings hold %s, so %s.", pattern, digitsText(d.Digits), eliminations)
+	case XYChainsStrategy:
+		return fmt.Sprintf("%s form an XY Chain; one of its ends must hold %s, so %s.", pattern, digitsText(d.Digits), eliminations)
+	case BackTrackingStrategy:
+		return "No logical deduction is available, the remaining cells are filled by trial and error."
+	}
+	if len(d.Pattern) == 0 {
+		return fmt.Sprintf("%s applies, so %s.", d.Strategy, eliminations)
+	}
+	return fmt.Sprintf("%s on %s over %s, so %s.", d.Strategy, pattern, digitsText(d.Digits), eliminations)
+}
+
+// eliminationsText describes the eliminations of the deduction, e.g. "5 is removed from r1c2 and {1,3} from r4c4"
+func (d Deduction) eliminationsText() string {
+	if len(d.Eliminations) == 0 {
+		return "no candidate is removed"
+	}
+	parts := make([]string, 0, len(d.Eliminations))
+	for _, elimination := range d.Eliminations {
+		parts = append(parts, fmt.Sprintf("%s from %s", digitsText(elimination.Marks), elimination.Cell))
+	}
+	verb := " is removed"
+	if len(parts) > 1 || d.Eliminations[0].Marks.GetCardinality() > 1 {
+		verb = " are removed"
+	}
+	first, rest := parts[0], parts[1:]
+	index := strings.Index(first, " from ")
+	first = first[:index] + verb + first[index:]
+	if len(rest) == 0 {
+		return first
+	}
+	return first + ", " + strings.Join(rest, ", ")
+}
+
+// digitsText returns a single digit as is and multiple digits in set notation
+func digitsText(digits CandidateSet) string {
+	if digits.GetCardinality() == 1 {
+		value, _ := digits.First()
+		return fmt.Sprintf("%d", value)
+	}
+	return digits.String()
+}
+
+// clustersText describes the colors of the clusters, e.g. "r1c1, r2c3 against r1c5"
+func clustersText(clusters []ColorCluster) string {
+	parts := make([]string, 0, len(clusters))
+	for _, cluster := range clusters {
+		parts = append(parts, joinRefs(cluster.Colors[0])+" against "+joinRefs(cluster.Colors[1]))
+	}
+	return strings.Join(parts, " and ")
+}
+
+func joinRefs(refs []CellRef) string {
+	parts := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		parts = append(parts, ref.String())
+	}
+	return strings.Join(parts, ", ")
+}
@@ -1,7 +1,5 @@
 package solver
 
-import "fmt"
-
 func EliminateLockedCandidates(b *Board) error {
 	for digit := 1; digit <= BoardSize; digit++ {
 		mark := CandidateSetOf(digit)
@@ -24,14 +22,18 @@ func eliminatePointingLockedCandidates(b *Board, mark CandidateSet) error {
 			}
 
 			if sameRow, row := confinedRow(cells); sameRow {
-				if err := eliminateMarkFromRowOutsideBox(b, row, boxCol/BlockSize, cells, mark); err != nil {
+				snapshot := snapshotMarks(b.row(row))
+				if err := eliminateMarkFromRowOutsideBox(b, row, boxIndex(boxRow, boxCol), cells, mark); err != nil {
 					return err
 				}
+				snapshot.record(b.recordDeduction, LockedCandidatesStrategy, cells, mark)
 			}
 			if sameCol, col := confinedCol(cells); sameCol {
-				if err := eliminateMarkFromColOutsideBox(b, col, boxRow/BlockSize, cells, mark); err != nil {
+				snapshot := snapshotMarks(b.col(col))
+				if err := eliminateMarkFromColOutsideBox(b, col, boxIndex(boxRow, boxCol), cells, mark); err != nil {
 					return err
 				}
+				snapshot.record(b.recordDeduction, LockedCandidatesStrategy, cells, mark)
 			}
 		}
 	}
@@ -45,9 +47,11 @@ func eliminateClaimingLockedCandidates(b *Board, mark CandidateSet) error {
 			continue
 		}
 		if sameBox, box := confinedBox(cells); sameBox {
+			snapshot := snapshotMarks(b.box(cells[0].Row, cells[0].Col))
 			if err := eliminateMarkFromBoxOutsideRow(b, row, box, cells, mark); err != nil {
 				return err
 			}
+			snapshot.record(b.recordDeduction, LockedCandidatesStrategy, cells, mark)
 		}
 	}
 
@@ -57,9 +61,11 @@ func eliminateClaimingLockedCandidates(b *Board, mark CandidateSet) error {
 			continue
 		}
 		if sameBox, box := confinedBox(cells); sameBox {
+			snapshot := snapshotMarks(b.box(cells[0].Row, cells[0].Col))
 			if err := eliminateMarkFromBoxOutsideCol(b, col, box, cells, mark); err != nil {
 				return err
 			}
+			snapshot.record(b.recordDeduction, LockedCandidatesStrategy, cells, mark)
 		}
 	}
 
@@ -120,7 +126,7 @@ func eliminateMarkFromRowOutsideBox(b *Board, row int, box int, protected []*Cel
 		if boxIndex(cell.Row, cell.Col) == box || IsCellInCollection(cell, protected) || cell.IsSolved() {
 			continue
 		}
-		if err := eliminateMarkFromCell(cell, mark, "Locked Candidates"); err != nil {
+		if err := eliminateMarkFromCell(cell, mark, LockedCandidatesStrategy); err != nil {
 			return err
 		}
 	}
@@ -132,7 +138,7 @@ func eliminateMarkFromColOutsideBox(b *Board, col int, box int, protected []*Cel
 		if boxIndex(cell.Row, cell.Col) == box || IsCellInCollection(cell, protected) || cell.IsSolved() {
 			continue
 		}
-		if err := eliminateMarkFromCell(cell, mark, "Locked Candidates"); err != nil {
+		if err := eliminateMarkFromCell(cell, mark, LockedCandidatesStrategy); err != nil {
 			return err
 		}
 	}
@@ -146,7 +152,7 @@ func eliminateMarkFromBoxOutsideRow(b *Board, row int, box int, protected []*Cel
 		if cell.Row == row || IsCellInCollection(cell, protected) || cell.IsSolved() {
 			continue
 		}
-		if err := eliminateMarkFromCell(cell, mark, "Locked Candidates"); err != nil {
+		if err := eliminateMarkFromCell(cell, mark, LockedCandidatesStrategy); err != nil {
 			return err
 		}
 	}
@@ -160,20 +166,20 @@ func eliminateMarkFromBoxOutsideCol(b *Board, col int, box int, protected []*Cel
 		if cell.Col == col || IsCellInCollection(cell, protected) || cell.IsSolved() {
 			continue
 		}
-		if err := eliminateMarkFromCell(cell, mark, "Locked Candidates"); err != nil {
+		if err := eliminateMarkFromCell(cell, mark, LockedCandidatesStrategy); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func eliminateMarkFromCell(cell *Cell, mark CandidateSet, strategy string) error {
+func eliminateMarkFromCell(cell *Cell, mark CandidateSet, strategy StrategyName) error {
 	if ParIntersect(cell.Marks, mark).IsEmpty() {
 		return nil
 	}
 	cell.Marks = cell.Marks.AndNot(mark)
 	if cell.Marks.IsEmpty() {
-		return fmt.Errorf("invalid board: %s: empty marks: cell: %+v", strategy, cell)
+		return emptyCandidates(cell, strategy)
 	}
 	return nil
 }
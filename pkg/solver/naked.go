@@ -1,26 +1,30 @@
 package solver
 
-import "fmt"
-
 // EliminateNakedPairs creates pairs combinations of marks/candidates on each unit (row, col or box)
 // if there are any pairs having the cardinality 2 (Unions of the three sets has exactly 2 different elements)
 // Then the other cell candidates within the unit having one of these elements is safely eliminated
 // Returns the number of eliminated candidates
 func EliminateNakedPairs(units [][]*Cell) error {
+	return eliminateNakedPairs(units, nil)
+}
+
+func eliminateNakedPairs(units [][]*Cell, record recordFunc) error {
 	for _, unit := range units {
 		unsolved := UnSolvedCells(unit)
 		pairs := PairCombinations(unsolved)
 		found, pair := IsNakedPairs(pairs)
 		if found {
 			marks := ParUnionCells(pair)
+			snapshot := snapshotMarks(unit)
 			for _, cell := range unit {
 				if !IsCellInCollection(cell, pair) && !cell.IsSolved() {
 					cell.Marks = cell.Marks.AndNot(marks)
 					if cell.Marks.IsEmpty() {
-						return fmt.Errorf("invalid board: NP: empty marks: cell: %+v", cell)
+						return emptyCandidates(cell, NakedPairsStrategy)
 					}
 				}
 			}
+			snapshot.record(record, NakedPairsStrategy, pair, marks)
 		}
 	}
 	return nil
@@ -43,20 +47,26 @@ func IsNakedPairs(combinations [][]*Cell) (bool, []*Cell) {
 // if there are any triplets having the cardinality 3 (Unions of the three sets has exactly 3 different elements)
 // Then the other cell candidates within the unit having one of these elements is safely eliminated
 func EliminateNakedTriplets(units [][]*Cell) error {
+	return eliminateNakedTriplets(units, nil)
+}
+
+func eliminateNakedTriplets(units [][]*Cell, record recordFunc) error {
 	for _, unit := range units {
 		unsolved := UnSolvedCells(unit)
 		triplets := TripletCombinations(unsolved)
 		found, triplet := IsNakedTriplet(triplets)
 		if found {
 			marks := ParUnionCells(triplet)
+			snapshot := snapshotMarks(unit)
 			for _, cell := range unit {
 				if !IsCellInCollection(cell, triplet) && !cell.IsSolved() {
 					cell.Marks = cell.Marks.AndNot(marks)
 					if cell.Marks.IsEmpty() {
-						return fmt.Errorf("invalid board: NT: empty marks: cell: %+v", cell)
+						return emptyCandidates(cell, NakedTriplesStrategy)
 					}
 				}
 			}
+			snapshot.record(record, NakedTriplesStrategy, triplet, marks)
 		}
 	}
 	return nil
@@ -78,20 +88,26 @@ func IsNakedTriplet(combinations [][]*Cell) (bool, []*Cell) {
 // Then the other cell candidates within the unit having one of these elements is safely eliminated
 // Returns the number of eliminated candidates
 func EliminateNakedQuads(units [][]*Cell) error {
+	return eliminateNakedQuads(units, nil)
+}
+
+func eliminateNakedQuads(units [][]*Cell, record recordFunc) error {
 	for _, unit := range units {
 		unsolved := UnSolvedCells(unit)
 		quads := QuadCombinations(unsolved)
 		found, quad := IsNakedQuad(quads)
 		if found {
 			marks := ParUnionCells(quad)
+			snapshot := snapshotMarks(unit)
 			for _, cell := range unit {
 				if !IsCellInCollection(cell, quad) && !cell.IsSolved() {
 					cell.Marks = cell.Marks.AndNot(marks)
 					if cell.Marks.IsEmpty() {
-						return fmt.Errorf("invalid board: NQ: empty marks: cell: %+v", cell)
+						return emptyCandidates(cell, NakedQuadsStrategy)
 					}
 				}
 			}
+			snapshot.record(record, NakedQuadsStrategy, quad, marks)
 		}
 	}
 	return nil
@@ -0,0 +1,152 @@
+package solver
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ParseOption configures the parsing of sudoku files
+type ParseOption func(*parseConfig)
+
+type parseConfig struct {
+	uniqueOnly bool
+	strict     bool
+	onError    func(error)
+}
+
+// RejectNonUnique makes the parser skip the boards having no solution or multiple solutions
+func RejectNonUnique() ParseOption {
+	return func(c *parseConfig) {
+		c.uniqueOnly = true
+	}
+}
+
+// Strict makes the parser stop at the first rejected line and return its *Diagnostic as the error
+func Strict() ParseOption {
+	return func(c *parseConfig) {
+		c.strict = true
+	}
+}
+
+// OnBoardError sets the handler called with a *Diagnostic for each line rejected by the parser
+func OnBoardError(handler func(error)) ParseOption {
+	return func(c *parseConfig) {
+		c.onError = handler
+	}
+}
+
+// Diagnostic keeps the line number of the input, the one based column of the offending cell and the reason why the
+// line is rejected. Column is 0 when the whole line is rejected and Err keeps the board error if there is one
+type Diagnostic struct {
+	Line   int
+	Column int
+	Reason string
+	Err    error
+}
+
+func (d *Diagnostic) Error() string {
+	if d.Column == 0 {
+		return fmt.Sprintf("line %d: %s", d.Line, d.Reason)
+	}
+	return fmt.Sprintf("line %d, column %d: %s", d.Line, d.Column, d.Reason)
+}
+
+func (d *Diagnostic) Unwrap() error {
+	return d.Err
+}
+
+// ParseString parses the given input with a board on each line, see Parse
+func ParseString(input string, options ...ParseOption) ([]*Board, []*Diagnostic, error) {
+	return Parse(strings.NewReader(input), options...)
+}
+
+// Parse reads a board from each line of the reader. The blank lines are ignored and the other rejected lines are
+// returned as diagnostics, in strict mode parsing stops at the first rejected line and its diagnostic is returned
+// as the error
+func Parse(r io.Reader, options ...ParseOption) ([]*Board, []*Diagnostic, error) {
+	config := &parseConfig{}
+	for _, option := range options {
+		option(config)
+	}
+
+	boards := make([]*Board, 0)
+	diagnostics := make([]*Diagnostic, 0)
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		board, diagnostic := parseLine(text, config)
+		if diagnostic == nil {
+			boards = append(boards, board)
+			continue
+		}
+		diagnostic.Line = line
+		diagnostics = append(diagnostics, diagnostic)
+		if config.onError != nil {
+			config.onError(diagnostic)
+		}
+		if config.strict {
+			return boards, diagnostics, diagnostic
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return boards, diagnostics, err
+	}
+	return boards, diagnostics, nil
+}
+
+// parseLine returns the board of a single line with 81 cells or the diagnostic of the line without its line number
+func parseLine(text string, config *parseConfig) (*Board, *Diagnostic) {
+	data, diagnostic := parseGivens(text)
+	if diagnostic != nil {
+		return nil, diagnostic
+	}
+
+	board, err := NewBoard(data)
+	if err == nil && config.uniqueOnly {
+		err = board.uniquenessError()
+	}
+	if err != nil {
+		return nil, &Diagnostic{Column: errorColumn(err), Reason: err.Error(), Err: err}
+	}
+	return board, nil
+}
+
+// parseGivens returns the values of a single line with 81 cells or the diagnostic of the line without its line number
+func parseGivens(text string) ([BoardSize][BoardSize]Value, *Diagnostic) {
+	var data [BoardSize][BoardSize]Value
+	for index := 0; index < len(text); index++ {
+		value, ok := HexMap[text[index]]
+		if !ok {
+			return data, &Diagnostic{Column: index + 1, Reason: fmt.Sprintf("invalid character %q", text[index])}
+		}
+		if index < BoardSize*BoardSize {
+			data[index/BoardSize][index%BoardSize] = value
+		}
+	}
+	if len(text) != BoardSize*BoardSize {
+		return data, &Diagnostic{
+			Column: min(len(text), BoardSize*BoardSize) + 1,
+			Reason: fmt.Sprintf("line has %d cells, want %d", len(text), BoardSize*BoardSize),
+		}
+	}
+	return data, nil
+}
+
+// errorColumn returns the one based column of the cell causing the board error or 0 if the error isn't about a cell
+func errorColumn(err error) int {
+	switch e := err.(type) {
+	case *ConflictError:
+		last := e.Cells[len(e.Cells)-1]
+		return last.Row*BoardSize + last.Col + 1
+	case *InvalidValueError:
+		return e.Cell.Row*BoardSize + e.Cell.Col + 1
+	}
+	return 0
+}
@@ -1,39 +1,54 @@
 package solver
 
-var peerIDs [BoardSize * BoardSize][]int
+const (
+	// CellCount is the number of the cells of the board
+	CellCount = BoardSize * BoardSize
+	// UnitCount is the number of the units of the board, the rows, the cols and the boxes
+	UnitCount = 3 * BoardSize
+	// PeerCount is the number of the cells sharing a unit with a cell
+	PeerCount = 20
+)
+
+// unitIDs keeps the cell ids of each unit in the order of Board.Units, the rows, the cols and then the boxes
+var unitIDs [UnitCount][BoardSize]int
+
+// cellUnitIDs keeps the row, col and box unit indexes of unitIDs for each cell
+var cellUnitIDs [CellCount][3]int
+
+// peerIDs keeps the cell ids sharing a unit with each cell, sorted by id
+var peerIDs [CellCount][PeerCount]int
 
 func init() {
 	for row := 0; row < BoardSize; row++ {
 		for col := 0; col < BoardSize; col++ {
 			id := cellID(row, col)
-			seen := make(map[int]struct{}, 20)
-			for i := 0; i < BoardSize; i++ {
-				if i != col {
-					seen[cellID(row, i)] = struct{}{}
-				}
-				if i != row {
-					seen[cellID(i, col)] = struct{}{}
-				}
-			}
-			rowMin := (row / BlockSize) * BlockSize
-			colMin := (col / BlockSize) * BlockSize
-			for r := rowMin; r < rowMin+BlockSize; r++ {
-				for c := colMin; c < colMin+BlockSize; c++ {
-					peer := cellID(r, c)
-					if peer != id {
-						seen[peer] = struct{}{}
-					}
-				}
-			}
-			peers := make([]int, 0, len(seen))
-			for peer := range seen {
-				peers = append(peers, peer)
+			box := boxIndex(row, col)
+			unitIDs[row][col] = id
+			unitIDs[BoardSize+col][row] = id
+			unitIDs[2*BoardSize+box][(row%BlockSize)*BlockSize+col%BlockSize] = id
+			cellUnitIDs[id] = [3]int{row, BoardSize + col, 2*BoardSize + box}
+		}
+	}
+	for id := 0; id < CellCount; id++ {
+		count := 0
+		for peer := 0; peer < CellCount; peer++ {
+			if peer != id && sharesUnit(id, peer) {
+				peerIDs[id][count] = peer
+				count++
 			}
-			peerIDs[id] = peers
 		}
 	}
 }
 
+func sharesUnit(a int, b int) bool {
+	for i := 0; i < 3; i++ {
+		if cellUnitIDs[a][i] == cellUnitIDs[b][i] {
+			return true
+		}
+	}
+	return false
+}
+
 func cellID(row int, col int) int {
 	return row*BoardSize + col
 }
@@ -0,0 +1,198 @@
+package solver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// CandidateStringLength is the length of the flat candidate format, nine characters for each cell
+const CandidateStringLength = BoardSize * BoardSize * BoardSize
+
+// NewBoardFromCandidates returns new Sudoku board with the given marks/candidates. The cells having a single candidate
+// are taken as solved, the other cells keep their marks so the solver continues from the narrowed candidates. The
+// marks carry the information of the missing clues, so there is no minimum number of solved cells
+func NewBoardFromCandidates(marks [BoardSize][BoardSize]CandidateSet) (*Board, error) {
+	var values [BoardSize][BoardSize]Value
+	for i := 0; i < BoardSize; i++ {
+		for j := 0; j < BoardSize; j++ {
+			if marks[i][j].IsEmpty() {
+				return nil, &EmptyCandidatesError{Cell: CellRef{Row: i, Col: j}}
+			}
+			if marks[i][j].GetCardinality() == 1 {
+				values[i][j], _ = marks[i][j].First()
+			}
+		}
+	}
+	board, err := newBoard(values, 0)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < BoardSize; i++ {
+		for j := 0; j < BoardSize; j++ {
+			if cell := board.data[i][j]; !cell.IsSolved() {
+				cell.Marks = marks[i][j]
+			}
+		}
+	}
+	board.keepMarks = true
+	return board, nil
+}
+
+// ParseCandidates parses a candidate grid either in the flat 729 character format or in the Hodoku/SudokuWiki
+// pencil-mark layout and returns a board keeping the candidates
+func ParseCandidates(input string) (*Board, error) {
+	trimmed := strings.TrimSpace(input)
+	if len(trimmed) == CandidateStringLength && !strings.ContainsAny(trimmed, "|\n") {
+		return parseCandidateString(trimmed)
+	}
+	return parsePencilMarkGrid(trimmed)
+}
+
+// parseCandidateString parses the flat format where each cell is nine characters, a digit or '.'/'0' for each
+// position
+func parseCandidateString(input string) (*Board, error) {
+	var marks [BoardSize][BoardSize]CandidateSet
+	for index := 0; index < BoardSize*BoardSize; index++ {
+		var set CandidateSet
+		for _, char := range []byte(input[index*BoardSize : (index+1)*BoardSize]) {
+			value, ok := HexMap[char]
+			if !ok {
+				return nil, fmt.Errorf("invalid candidate character %q in cell %d", char, index)
+			}
+			set = set.Add(int(value))
+		}
+		marks[index/BoardSize][index%BoardSize] = set
+	}
+	return NewBoardFromCandidates(marks)
+}
+
+// parsePencilMarkGrid parses the layouts with one line per row and the candidates of each cell written together,
+// the border lines and '|' separators are ignored
+func parsePencilMarkGrid(input string) (*Board, error) {
+	var marks [BoardSize][BoardSize]CandidateSet
+	row := 0
+	for _, line := range strings.Split(input, "\n") {
+		if !strings.ContainsAny(line, "123456789") {
+			continue
+		}
+		if row == BoardSize {
+			return nil, errors.New("pencil-mark grid has more than 9 rows")
+		}
+		fields := strings.Fields(strings.Map(func(r rune) rune {
+			if r >= '1' && r <= '9' {
+				return r
+			}
+			return ' '
+		}, line))
+		if len(fields) != BoardSize {
+			return nil, fmt.Errorf("pencil-mark grid row %d has %d cells, want %d", row+1, len(fields), BoardSize)
+		}
+		for col, field := range fields {
+			for _, char := range field {
+				marks[row][col] = marks[row][col].Add(int(char - '0'))
+			}
+		}
+		row++
+	}
+	if row != BoardSize {
+		return nil, fmt.Errorf("pencil-mark grid has %d rows, want %d", row, BoardSize)
+	}
+	return NewBoardFromCandidates(marks)
+}
+
+// CandidateString returns the candidates of the board in the flat 729 character format
+func (b *Board) CandidateString() string {
+	var builder strings.Builder
+	builder.Grow(CandidateStringLength)
+	for i := 0; i < BoardSize; i++ {
+		for j := 0; j < BoardSize; j++ {
+			marks := b.cellCandidates(b.data[i][j])
+			for digit := 1; digit <= BoardSize; digit++ {
+				if marks.Contains(digit) {
+					builder.WriteByte(byte('0' + digit))
+				} else {
+					builder.WriteByte('.')
+				}
+			}
+		}
+	}
+	return builder.String()
+}
+
+// PencilMarks returns the candidates of the board in the Hodoku pencil-mark layout
+func (b *Board) PencilMarks() string {
+	var cells [BoardSize][BoardSize]string
+	var widths [BoardSize]int
+	for i := 0; i < BoardSize; i++ {
+		for j := 0; j < BoardSize; j++ {
+			marks := b.cellCandidates(b.data[i][j])
+			cells[i][j] = marksDigits(marks)
+			widths[j] = max(widths[j], len(cells[i][j]))
+		}
+	}
+
+	var builder strings.Builder
+	for i := 0; i < BoardSize; i++ {
+		switch i {
+		case 0:
+			builder.WriteString(pencilMarkBorder(widths, '.', '.'))
+		case BlockSize, 2 * BlockSize:
+			builder.WriteString(pencilMarkBorder(widths, ':', '+'))
+		}
+		for j := 0; j < BoardSize; j++ {
+			if j%BlockSize == 0 {
+				builder.WriteString("|")
+			}
+			builder.WriteString(" ")
+			builder.WriteString(cells[i][j])
+			builder.WriteString(strings.Repeat(" ", widths[j]-len(cells[i][j])))
+			if j%BlockSize == BlockSize-1 {
+				builder.WriteString(" ")
+			}
+		}
+		builder.WriteString("|\n")
+	}
+	builder.WriteString(pencilMarkBorder(widths, '\'', '\''))
+	return builder.String()
+}
+
+// pencilMarkBorder returns a border line of the pencil-mark layout with the given edge and inner box corners
+func pencilMarkBorder(widths [BoardSize]int, edge byte, corner byte) string {
+	var builder strings.Builder
+	builder.WriteByte(edge)
+	for box := 0; box < BlockSize; box++ {
+		length := 1
+		for j := box * BlockSize; j < (box+1)*BlockSize; j++ {
+			length += widths[j] + 1
+		}
+		builder.WriteString(strings.Repeat("-", length))
+		if box < BlockSize-1 {
+			builder.WriteByte(corner)
+		}
+	}
+	builder.WriteByte(edge)
+	builder.WriteByte(EOL)
+	return builder.String()
+}
+
+// cellCandidates returns the value of a solved cell as a single candidate, the marks of an unsolved cell or the
+// computed candidates if its marks are not initialized yet
+func (b *Board) cellCandidates(cell *Cell) CandidateSet {
+	if cell.IsSolved() {
+		return CandidateSetOf(int(cell.Value))
+	}
+	if cell.Marks.IsEmpty() {
+		return cell.ComputeCellMarks(b)
+	}
+	return cell.Marks
+}
+
+// marksDigits returns the candidates written together, e.g. "159"
+func marksDigits(marks CandidateSet) string {
+	digits := make([]byte, 0, marks.GetCardinality())
+	for _, digit := range marks.ToArray() {
+		digits = append(digits, byte('0'+digit))
+	}
+	return string(digits)
+}
@@ -0,0 +1,149 @@
+package solver
+
+import (
+	"context"
+	"slices"
+)
+
+const (
+	// frequencyWeight is the share of each advanced strategy application added on top of the hardest strategy weight
+	frequencyWeight = 0.01
+	// defaultStrategyWeight is used for the strategies which are not listed in strategyWeights
+	defaultStrategyWeight = 5.0
+)
+
+// strategyWeights keeps the weight of each strategy, the values follow the Sudoku Explainer ratings
+var strategyWeights = map[StrategyName]float64{
+	HiddenSingleStrategy:     1.5,
+	NakedSingleStrategy:      2.3,
+	LockedCandidatesStrategy: 2.8,
+	NakedPairsStrategy:       3.0,
+	XWingsStrategy:           3.2,
+	FinnedXWingsStrategy:     3.4,
+	HiddenPairsStrategy:      3.4,
+	NakedTriplesStrategy:     3.6,
+	SwordFishStrategy:        3.8,
+	FinnedSwordFishStrategy:  4.0,
+	HiddenTripletsStrategy:   4.0,
+	SkyscraperStrategy:       4.0,
+	TwoStringKiteStrategy:    4.1,
+	EmptyRectangleStrategy:   4.2,
+	XYWingsStrategy:          4.2,
+	ColoringStrategy:         4.3,
+	XYZWingsStrategy:         4.4,
+	WWingsStrategy:           4.4,
+	XYChainsStrategy:         4.6,
+	NakedQuadsStrategy:       5.0,
+	JellyfishStrategy:        5.2,
+	FinnedJellyfishStrategy:  5.4,
+	HiddenQuadsStrategy:      5.4,
+	BackTrackingStrategy:     10.0,
+}
+
+// ratingLevels keeps the lowest score of each difficulty level. Evil starts at the weight of the hardest logical
+// strategies, so a puzzle the logic finishes can be Evil too
+var ratingLevels = []struct {
+	difficulty Difficulty
+	score      float64
+}{
+	{difficulty: Evil, score: 5.0},
+	{difficulty: Expert, score: 4.2},
+	{difficulty: Hard, score: 3.6},
+	{difficulty: Medium, score: 2.5},
+	{difficulty: Easy, score: 0},
+}
+
+// Rating is the strategy based difficulty rating of a puzzle. Score is the weight of the hardest strategy needed
+// plus a small share of the weights of every strategy application beyond the singles
+type Rating struct {
+	Score            float64
+	Difficulty       Difficulty
+	HardestStrategy  StrategyName
+	StrategyCounts   map[StrategyName]int
+	BackTrackingUsed bool
+}
+
+// Rate rates the board's current state by solving a copy of it with the strategies applied from the easiest to the
+// hardest one. Backtracking is counted as unavoidable when the strategies stall
+func Rate(board *Board) (*Rating, error) {
+	return RateContext(context.Background(), board)
+}
+
+// RateContext rates the board like Rate and stops with a *CanceledError as soon as the context is done
+func RateContext(ctx context.Context, board *Board) (*Rating, error) {
+	return rate(ctx, board, orderedStrategies)
+}
+
+// Rate rates the board like Rate with the strategy pipeline of the solver
+func (s *Solver) Rate(board *Board) (*Rating, error) {
+	return s.RateContext(context.Background(), board)
+}
+
+// RateContext rates the board like Solver.Rate and stops with a *CanceledError as soon as the context is done
+func (s *Solver) RateContext(ctx context.Context, board *Board) (*Rating, error) {
+	return rate(ctx, board, s.strategies)
+}
+
+func rate(ctx context.Context, board *Board, strategies []Strategy) (*Rating, error) {
+	clone := board.clone()
+	if err := clone.run(ctx, solveConfig{strategies: hintStrategies(strategies), backtrack: false}); err != nil {
+		return nil, err
+	}
+	return clone.traceRating(), nil
+}
+
+// gradedOrder reports whether the strategies already run from the easiest to the hardest one, the trace of such a
+// pipeline rates the puzzle like rate does
+func gradedOrder(strategies []Strategy) bool {
+	return slices.IsSortedFunc(strategies, func(a, b Strategy) int {
+		return hintRank(a.Name()) - hintRank(b.Name())
+	})
+}
+
+// traceRating rates the board from the deductions of its solve, backtracking is counted as unavoidable when the
+// strategies stalled
+func (b *Board) traceRating() *Rating {
+	trace := b.trace
+	if !b.isSolved() && !b.backTrackUsed {
+		trace = append(slices.Clone(trace), Deduction{Strategy: BackTrackingStrategy})
+	}
+	return rateTrace(trace)
+}
+
+// rateTrace computes the rating of the given deductions
+func rateTrace(trace []Deduction) *Rating {
+	rating := &Rating{
+		StrategyCounts: make(map[StrategyName]int),
+	}
+	hardest := 0.0
+	total := 0.0
+	for _, deduction := range trace {
+		weight := strategyWeight(deduction.Strategy)
+		rating.StrategyCounts[deduction.Strategy]++
+		if weight > strategyWeights[NakedSingleStrategy] {
+			total += weight
+		}
+		if weight > hardest {
+			hardest = weight
+			rating.HardestStrategy = deduction.Strategy
+		}
+		if deduction.Strategy == BackTrackingStrategy {
+			rating.BackTrackingUsed = true
+		}
+	}
+	rating.Score = hardest + frequencyWeight*total
+	for _, level := range ratingLevels {
+		if rating.Score >= level.score {
+			rating.Difficulty = level.difficulty
+			break
+		}
+	}
+	return rating
+}
+
+func strategyWeight(name StrategyName) float64 {
+	if weight, ok := strategyWeights[name]; ok {
+		return weight
+	}
+	return defaultStrategyWeight
+}
@@ -67,6 +67,11 @@ func (s CandidateSet) First() (Value, bool) {
 	return EmptyCellValue, false
 }
 
+// lowest returns the smallest digit of the set, the set shouldn't be empty
+func (s CandidateSet) lowest() Value {
+	return Value(bits.TrailingZeros16(uint16(s)))
+}
+
 func (s CandidateSet) And(other CandidateSet) CandidateSet {
 	return s & other
 }
@@ -0,0 +1,188 @@
+package solver
+
+// conjugatePair is a strong link of a digit, the only two cells of a unit holding it, so one of them holds the digit
+type conjugatePair [2]*Cell
+
+// lineConjugatePairs returns the conjugate pairs of the mark within the rows or the cols
+func lineConjugatePairs(b *Board, mark CandidateSet, byRows bool) []conjugatePair {
+	pairs := make([]conjugatePair, 0)
+	for i := 0; i < BoardSize; i++ {
+		if cells := candidateCellsForMark(line(b, i, byRows), mark); len(cells) == 2 {
+			pairs = append(pairs, conjugatePair{cells[0], cells[1]})
+		}
+	}
+	return pairs
+}
+
+// EliminateSkyscrapers eliminates marks/candidates using Skyscrapers. Two conjugate pairs of a digit in parallel lines
+// share the orthogonal line of one end, the base, so one of the other ends, the tops, holds the digit and the cells
+// seeing both tops lose it
+func EliminateSkyscrapers(b *Board) error {
+	for _, byRows := range []bool{true, false} {
+		for digit := 1; digit <= BoardSize; digit++ {
+			mark := CandidateSetOf(digit)
+			pairs := lineConjugatePairs(b, mark, byRows)
+			for i := 0; i < len(pairs)-1; i++ {
+				for j := i + 1; j < len(pairs); j++ {
+					for _, ends := range [][2]int{{0, 0}, {0, 1}, {1, 0}, {1, 1}} {
+						base, otherBase := pairs[i][ends[0]], pairs[j][ends[1]]
+						top, otherTop := pairs[i][1-ends[0]], pairs[j][1-ends[1]]
+						if orthogonalIndex(base, byRows) != orthogonalIndex(otherBase, byRows) ||
+							orthogonalIndex(top, byRows) == orthogonalIndex(otherTop, byRows) {
+							continue
+						}
+						pattern := []*Cell{base, top, otherBase, otherTop}
+						targets := commonPeers(b, []*Cell{top, otherTop}, pattern, mark)
+						if err := eliminateFromTargets(b.recordDeduction, SkyscraperStrategy, pattern, targets, mark); err != nil {
+							return err
+						}
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// EliminateTwoStringKites eliminates marks/candidates using 2-String Kites. A row conjugate pair and a col conjugate
+// pair of a digit have an end each in the same box, at most one of these ends holds the digit, so one of the other
+// ends does and the cells seeing both of them lose it
+func EliminateTwoStringKites(b *Board) error {
+	for digit := 1; digit <= BoardSize; digit++ {
+		mark := CandidateSetOf(digit)
+		colPairs := lineConjugatePairs(b, mark, false)
+		for _, rowPair := range lineConjugatePairs(b, mark, true) {
+			for _, colPair := range colPairs {
+				if IsCellInCollection(rowPair[0], colPair[:]) || IsCellInCollection(rowPair[1], colPair[:]) {
+					continue
+				}
+				for _, ends := range [][2]int{{0, 0}, {0, 1}, {1, 0}, {1, 1}} {
+					rowEnd, colEnd := rowPair[ends[0]], colPair[ends[1]]
+					if boxIndex(rowEnd.Row, rowEnd.Col) != boxIndex(colEnd.Row, colEnd.Col) {
+						continue
+					}
+					rowString, colString := rowPair[1-ends[0]], colPair[1-ends[1]]
+					pattern := []*Cell{rowString, rowEnd, colEnd, colString}
+					targets := commonPeers(b, []*Cell{rowString, colString}, pattern, mark)
+					if err := eliminateFromTargets(b.recordDeduction, TwoStringKiteStrategy, pattern, targets, mark); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// EliminateEmptyRectangles eliminates marks/candidates using Empty Rectangles. The candidates of a digit in a box lie
+// in one row and one col of the box. A conjugate pair of the digit outside the box with an end, the hinge, on that
+// row removes the digit from the col of the box in the line of its other end, and the same for the col
+func EliminateEmptyRectangles(b *Board) error {
+	for digit := 1; digit <= BoardSize; digit++ {
+		mark := CandidateSetOf(digit)
+		rowPairs := lineConjugatePairs(b, mark, true)
+		colPairs := lineConjugatePairs(b, mark, false)
+		for box := 0; box < BoardSize; box++ {
+			boxRow, boxCol := (box/BlockSize)*BlockSize, (box%BlockSize)*BlockSize
+			cells := candidateCellsForMark(b.box(boxRow, boxCol), mark)
+			if len(cells) < 2 {
+				continue
+			}
+			for row := boxRow; row < boxRow+BlockSize; row++ {
+				for col := boxCol; col < boxCol+BlockSize; col++ {
+					if !isEmptyRectangle(cells, row, col) {
+						continue
+					}
+					for _, pair := range colPairs {
+						if err := eliminateEmptyRectangle(b, cells, pair, mark, row, col, true); err != nil {
+							return err
+						}
+					}
+					for _, pair := range rowPairs {
+						if err := eliminateEmptyRectangle(b, cells, pair, mark, row, col, false); err != nil {
+							return err
+						}
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// isEmptyRectangle reports whether the box candidates lie in the given row and col, with at least one of them in the
+// row and one in the col outside their intersection
+func isEmptyRectangle(cells []*Cell, row int, col int) bool {
+	inRow, inCol := false, false
+	for _, cell := range cells {
+		switch {
+		case cell.Row == row && cell.Col == col:
+		case cell.Row == row:
+			inRow = true
+		case cell.Col == col:
+			inCol = true
+		default:
+			return false
+		}
+	}
+	return inRow && inCol
+}
+
+// eliminateEmptyRectangle removes the mark seen by the box candidates and the far end of the pair. A col pair needs its
+// hinge on the row of the rectangle, its far end then points at the col of the rectangle, a row pair the other way
+func eliminateEmptyRectangle(b *Board, cells []*Cell, pair conjugatePair, mark CandidateSet, row int, col int, colPair bool) error {
+	box := boxIndex(row, col)
+	for end := range pair {
+		hinge, far := pair[end], pair[1-end]
+		var target *Cell
+		if colPair {
+			if hinge.Row != row || hinge.Col/BlockSize == col/BlockSize || far.Row/BlockSize == row/BlockSize {
+				continue
+			}
+			target = b.data[far.Row][col]
+		} else {
+			if hinge.Col != col || hinge.Row/BlockSize == row/BlockSize || far.Col/BlockSize == col/BlockSize {
+				continue
+			}
+			target = b.data[row][far.Col]
+		}
+		if target.IsSolved() || ParIntersect(target.Marks, mark).IsEmpty() || boxIndex(target.Row, target.Col) == box {
+			continue
+		}
+		pattern := append(append(make([]*Cell, 0, len(cells)+2), cells...), hinge, far)
+		if err := eliminateFromTargets(b.recordDeduction, EmptyRectangleStrategy, pattern, []*Cell{target}, mark); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// commonPeers returns the unsolved cells holding the mark which see each of the ends, the pattern cells excluded
+func commonPeers(b *Board, ends []*Cell, pattern []*Cell, mark CandidateSet) []*Cell {
+	targets := make([]*Cell, 0)
+	for _, id := range peerIDs[ends[0].ID] {
+		cell := &b.cells[id]
+		if cell.IsSolved() || ParIntersect(cell.Marks, mark).IsEmpty() || IsCellInCollection(cell, pattern) {
+			continue
+		}
+		if seesAll(cell, ends[1:]) {
+			targets = append(targets, cell)
+		}
+	}
+	return targets
+}
+
+// eliminateFromTargets removes the mark from the targets and records the deduction of the pattern
+func eliminateFromTargets(record recordFunc, strategy StrategyName, pattern []*Cell, targets []*Cell, mark CandidateSet) error {
+	if len(targets) == 0 {
+		return nil
+	}
+	snapshot := snapshotMarks(targets)
+	for _, cell := range targets {
+		if err := eliminateMarkFromCell(cell, mark, strategy); err != nil {
+			return err
+		}
+	}
+	snapshot.record(record, strategy, pattern, mark)
+	return nil
+}
@@ -1,28 +1,78 @@
 package solver
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 const stalledCycleThreshold = 1
 
 // Solve is a utility function to start the solving process of given sudoku board.
 func (b *Board) Solve() *SolveResponse {
+	return b.SolveContext(context.Background())
+}
+
+// SolveContext solves the board like Solve, but stops as soon as the context is done. The cancellation is checked
+// between the strategy passes and inside the backtracking, the response keeps a *CanceledError and the partial grid
+func (b *Board) SolveContext(ctx context.Context) *SolveResponse {
+	return b.solve(ctx, solveConfig{strategies: orderedStrategies, backtrack: true})
+}
+
+// SolveLogical solves the board like Solve, but never guesses. When the strategies stall the response keeps the
+// stalled grid with its marks/candidates, the open cells and the strategies tried in Stall
+func (b *Board) SolveLogical() *SolveResponse {
+	return b.solve(context.Background(), solveConfig{strategies: orderedStrategies, backtrack: false})
+}
+
+// SolveGraded solves the board by applying the strategies from the easiest to the hardest one, so the strategies used
+// are the ones the puzzle actually needs rather than the first ones in the pipeline
+func (b *Board) SolveGraded() *SolveResponse {
+	return b.solve(context.Background(), solveConfig{strategies: hintStrategies(orderedStrategies), backtrack: true})
+}
+
+// solveConfig keeps the strategy pipeline, whether backtracking is allowed once the strategies stall and the search
+// backend used for it
+type solveConfig struct {
+	strategies []Strategy
+	backtrack  bool
+	backend    Backend
+}
+
+func (b *Board) solve(ctx context.Context, config solveConfig) *SolveResponse {
 	begin := time.Now()
+	graded := gradedOrder(config.strategies)
+	var initial *Board
+	if !graded {
+		initial = b.clone()
+	}
+	err := b.run(ctx, config)
+	if err == nil {
+		if graded {
+			b.rating = b.traceRating()
+		} else {
+			b.rating, err = rate(ctx, initial, config.strategies)
+		}
+	}
+	return b.buildSolveResponse(begin, err)
+}
 
+// run applies the singles and the strategies until the board is solved or the strategies stall
+func (b *Board) run(ctx context.Context, config solveConfig) error {
 	if err := b.validateForSolve(); err != nil {
-		return b.buildSolveResponse(begin, err)
+		return err
 	}
 	if err := b.initializeCandidates(); err != nil {
-		return b.buildSolveResponse(begin, err)
-	}
-	if b.isSolved() {
-		return b.buildSolveResponse(begin, nil)
+		return err
 	}
 
 	stalledCycles := 0
 	for !b.isSolved() {
+		if err := canceled(ctx); err != nil {
+			return err
+		}
 		changed, err := b.resolveSingles()
 		if err != nil {
-			return b.buildSolveResponse(begin, err)
+			return err
 		}
 		if b.isSolved() {
 			break
@@ -32,9 +82,9 @@ func (b *Board) Solve() *SolveResponse {
 			continue
 		}
 
-		changed, err = b.applyStrategies()
+		changed, err = b.applyStrategies(config.strategies)
 		if err != nil {
-			return b.buildSolveResponse(begin, err)
+			return err
 		}
 		if b.isSolved() {
 			break
@@ -46,28 +96,56 @@ func (b *Board) Solve() *SolveResponse {
 
 		stalledCycles++
 		if stalledCycles >= stalledCycleThreshold {
-			b.backTrack()
+			if config.backtrack {
+				return b.backTrack(ctx, config.backend)
+			}
+			b.stall = b.newStall(config.strategies)
 			break
 		}
 	}
+	return nil
+}
 
-	return b.buildSolveResponse(begin, nil)
+// SolveUnique solves the board like Solve, but rejects the board when it has no solution or multiple solutions
+// instead of returning one of them
+func (b *Board) SolveUnique() *SolveResponse {
+	begin := time.Now()
+	if err := b.uniquenessError(); err != nil {
+		return b.buildSolveResponse(begin, err)
+	}
+	return b.Solve()
 }
 
 func (b *Board) buildSolveResponse(begin time.Time, err error) *SolveResponse {
+	if err == nil && b.stall != nil {
+		err = b.stall.err()
+	}
 	if err == nil && !b.isSolved() {
 		err = b.solveError()
 	}
 
+	difficulty := Levels[b.difficulty]
+	if b.rating != nil {
+		difficulty = Levels[b.rating.Difficulty]
+	}
+	elapsed := time.Since(begin)
+
 	return &SolveResponse{
-		Difficulty:       Levels[b.difficulty],
+		Difficulty:       difficulty,
+		ClueDifficulty:   Levels[b.difficulty],
+		Rating:           b.rating,
 		Givens:           b.givens,
 		InitialState:     b.initialState,
 		Solution:         b.getState(),
-		Duration:         time.Since(begin).Seconds(),
+		InitialGrid:      GridString(b.input),
+		SolutionGrid:     GridString(b.Values()),
+		Duration:         elapsed.Seconds(),
+		Elapsed:          elapsed,
 		IsSolved:         err == nil,
 		BackTrackingUsed: b.backTrackUsed,
 		StrategiesUsed:   b.strategiesUsed,
+		SolveTrace:       b.trace,
+		Stall:            b.stall,
 		Error:            err,
 	}
 }
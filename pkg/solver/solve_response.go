@@ -3,17 +3,27 @@ package solver
 import (
 	"strconv"
 	"strings"
+	"time"
 )
 
+// SolveResponse is the result of a solve. InitialState and Solution are the printouts of the board while InitialGrid
+// and SolutionGrid keep the same states in the 81 character line format
 type SolveResponse struct {
 	Difficulty       string
+	ClueDifficulty   string
+	Rating           *Rating
 	Givens           int
 	InitialState     string
 	Solution         string
+	InitialGrid      string
+	SolutionGrid     string
 	Duration         float64
+	Elapsed          time.Duration
 	IsSolved         bool
 	BackTrackingUsed bool
 	StrategiesUsed   []string
+	SolveTrace       []Deduction
+	Stall            *Stall
 	Error            error
 }
 
@@ -22,6 +32,16 @@ func (r *SolveResponse) Print() string {
 	builder.WriteString("Difficulty: ")
 	builder.WriteString(r.Difficulty)
 	builder.WriteByte('\n')
+	if r.Rating != nil {
+		builder.WriteString("Rating: ")
+		builder.WriteString(strconv.FormatFloat(r.Rating.Score, 'f', 2, 64))
+		builder.WriteString(" (hardest: ")
+		builder.WriteString(r.Rating.HardestStrategy.String())
+		builder.WriteString(")\n")
+	}
+	builder.WriteString("Clue difficulty: ")
+	builder.WriteString(r.ClueDifficulty)
+	builder.WriteByte('\n')
 	builder.WriteString("Givens: ")
 	builder.WriteString(strconv.Itoa(r.Givens))
 	builder.WriteByte('\n')
@@ -37,6 +57,16 @@ func (r *SolveResponse) Print() string {
 	builder.WriteString("Duration: ")
 	builder.WriteString(strconv.FormatFloat(r.Duration, 'f', 2, 64))
 	builder.WriteString(" seconds\n")
+	if r.Stall != nil {
+		builder.WriteString("Open cells: ")
+		builder.WriteString(strconv.Itoa(len(r.Stall.OpenCells)))
+		builder.WriteByte('\n')
+		builder.WriteString("Strategies tried: [")
+		builder.WriteString(strings.Join(strategyNames(r.Stall.StrategiesTried), ", "))
+		builder.WriteString("]\n")
+		builder.WriteString("Candidates: \n")
+		builder.WriteString(r.Stall.Candidates)
+	}
 	if r.Error != nil {
 		builder.WriteString("Error: ")
 		builder.WriteString(r.Error.Error())
@@ -55,3 +85,11 @@ func (r *SolveResponse) Print() string {
 
 	return builder.String()
 }
+
+func strategyNames(strategies []StrategyName) []string {
+	names := make([]string, 0, len(strategies))
+	for _, strategy := range strategies {
+		names = append(names, strategy.String())
+	}
+	return names
+}
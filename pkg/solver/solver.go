@@ -0,0 +1,219 @@
+package solver
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+var (
+	registryMutex sync.RWMutex
+	registry      = make(map[StrategyName]Strategy)
+	registryOrder = make([]StrategyName, 0)
+)
+
+func init() {
+	for _, strategy := range orderedStrategies {
+		if err := RegisterStrategy(strategy); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// RegisterStrategy adds the given strategy into the registry so it can be selected by its name with WithStrategies
+func RegisterStrategy(strategy Strategy) error {
+	if strategy == nil || strategy.Name() == "" {
+		return fmt.Errorf("strategy should have a name: %+v", strategy)
+	}
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+	if _, ok := registry[strategy.Name()]; ok {
+		return fmt.Errorf("strategy %q is already registered", strategy.Name())
+	}
+	registry[strategy.Name()] = strategy
+	registryOrder = append(registryOrder, strategy.Name())
+	return nil
+}
+
+// LookupStrategy returns the registered strategy with the given name
+func LookupStrategy(name StrategyName) (Strategy, bool) {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+	strategy, ok := registry[name]
+	return strategy, ok
+}
+
+// RegisteredStrategies returns the names of the registered strategies in registration order
+func RegisteredStrategies() []StrategyName {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+	return slices.Clone(registryOrder)
+}
+
+// DefaultStrategies returns the names of the strategies used by Board.Solve in their order
+func DefaultStrategies() []StrategyName {
+	names := make([]StrategyName, 0, len(orderedStrategies))
+	for _, strategy := range orderedStrategies {
+		names = append(names, strategy.Name())
+	}
+	return names
+}
+
+// Option configures a Solver
+type Option func(*Solver) error
+
+// Solver solves boards with a configurable strategy pipeline
+type Solver struct {
+	strategies     []Strategy
+	backtrack      bool
+	backend        Backend
+	maxChainLength int
+}
+
+// New returns a solver using the default strategy pipeline with backtracking, changed by the given options
+func New(options ...Option) (*Solver, error) {
+	s := &Solver{
+		strategies:     slices.Clone(orderedStrategies),
+		backtrack:      true,
+		maxChainLength: DefaultMaxChainLength,
+	}
+	return s.With(options...)
+}
+
+// With returns a copy of the solver changed by the given options, the solver itself is left as it is. The copy keeps
+// the custom strategies, the backend and the max chain length of the solver unless the options change them
+func (s *Solver) With(options ...Option) (*Solver, error) {
+	derived := *s
+	derived.strategies = slices.Clone(s.strategies)
+	for _, option := range options {
+		if err := option(&derived); err != nil {
+			return nil, err
+		}
+	}
+	for i, strategy := range derived.strategies {
+		if _, ok := strategy.(strategyFunc); ok && strategy.Name() == XYChainsStrategy {
+			derived.strategies[i] = xyChainsStrategy(derived.maxChainLength)
+		}
+	}
+	return &derived, nil
+}
+
+// WithStrategies replaces the pipeline with the registered strategies of the given names in the given order
+func WithStrategies(names ...StrategyName) Option {
+	return func(s *Solver) error {
+		strategies := make([]Strategy, 0, len(names))
+		for _, name := range names {
+			strategy, ok := LookupStrategy(name)
+			if !ok {
+				return fmt.Errorf("unknown strategy: %q", name)
+			}
+			strategies = append(strategies, strategy)
+		}
+		s.strategies = strategies
+		return nil
+	}
+}
+
+// WithStrategy appends the given strategy to the end of the pipeline, it doesn't need to be registered
+func WithStrategy(custom Strategy) Option {
+	return func(s *Solver) error {
+		if custom == nil || custom.Name() == "" {
+			return fmt.Errorf("strategy should have a name: %+v", custom)
+		}
+		s.strategies = append(s.strategies, custom)
+		return nil
+	}
+}
+
+// WithoutStrategies removes the strategies of the given names from the pipeline
+func WithoutStrategies(names ...StrategyName) Option {
+	return func(s *Solver) error {
+		s.strategies = slices.DeleteFunc(s.strategies, func(strategy Strategy) bool {
+			return slices.Contains(names, strategy.Name())
+		})
+		return nil
+	}
+}
+
+// WithoutBacktracking makes the solver stop when the strategies stall instead of falling back to backtracking
+func WithoutBacktracking() Option {
+	return func(s *Solver) error {
+		s.backtrack = false
+		return nil
+	}
+}
+
+// WithBackend selects the search used once the strategies stall and by CountSolutions, BitBoardBackend by default
+func WithBackend(backend Backend) Option {
+	return func(s *Solver) error {
+		if _, ok := Backends[backend]; !ok {
+			return fmt.Errorf("unknown backend: %d", backend)
+		}
+		s.backend = backend
+		return nil
+	}
+}
+
+// WithMaxChainLength sets the longest XY Chain, in cells, searched by the pipeline, DefaultMaxChainLength by default.
+// It applies to the XY Chains strategy wherever it is in the pipeline, whatever the order of the options
+func WithMaxChainLength(length int) Option {
+	return func(s *Solver) error {
+		if length < minChainLength {
+			return fmt.Errorf("max chain length should be at least %d, found %d", minChainLength, length)
+		}
+		s.maxChainLength = length
+		return nil
+	}
+}
+
+// Backend returns the search backend of the solver
+func (s *Solver) Backend() Backend {
+	return s.backend
+}
+
+// MaxChainLength returns the longest XY Chain, in cells, searched by the solver
+func (s *Solver) MaxChainLength() int {
+	return s.maxChainLength
+}
+
+// Strategies returns the names of the strategies in the pipeline of the solver
+func (s *Solver) Strategies() []StrategyName {
+	names := make([]StrategyName, 0, len(s.strategies))
+	for _, strategy := range s.strategies {
+		names = append(names, strategy.Name())
+	}
+	return names
+}
+
+// Solve solves the given board with the pipeline of the solver
+func (s *Solver) Solve(board *Board) *SolveResponse {
+	return s.SolveContext(context.Background(), board)
+}
+
+// SolveContext solves the given board with the pipeline of the solver and stops as soon as the context is done
+func (s *Solver) SolveContext(ctx context.Context, board *Board) *SolveResponse {
+	return board.solve(ctx, s.config())
+}
+
+// Hint returns the easiest deduction the pipeline of the solver finds on the given board without changing the board
+func (s *Solver) Hint(board *Board) (*Hint, error) {
+	return board.hint(s.strategies)
+}
+
+// CountSolutions counts the solutions of the board's current state with the backend of the solver, the search stops
+// as soon as limit solutions are found
+func (s *Solver) CountSolutions(board *Board, limit int) int {
+	count, _ := s.CountSolutionsContext(context.Background(), board, limit)
+	return count
+}
+
+// CountSolutionsContext counts the solutions like CountSolutions and stops with a *CanceledError as soon as the
+// context is done
+func (s *Solver) CountSolutionsContext(ctx context.Context, board *Board, limit int) (int, error) {
+	return s.backend.count(ctx, board.Grid(), limit)
+}
+
+func (s *Solver) config() solveConfig {
+	return solveConfig{strategies: s.strategies, backtrack: s.backtrack, backend: s.backend}
+}
@@ -1,9 +1,16 @@
 package solver
 
 import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 )
 
 const solvedBoard = "483921657967345821251876493548132976729564138136798245372689514814253769695417382"
@@ -107,13 +114,14 @@ func TestParseFileSkipsInvalidLinesAndParsesValidBoards(t *testing.T) {
 	}
 }
 
-func TestNextBacktrackCellChoosesMinimumCandidateCell(t *testing.T) {
+func TestNextGridCellChoosesMinimumCandidateCell(t *testing.T) {
 	board, err := NewBoard(mustGridFromString(t, "003020600900305001001806400008102900700000008006708200002609500800203009005010300"))
 	if err != nil {
 		t.Fatalf("NewBoard() error = %v", err)
 	}
 
-	solved, valid, row, col, marks := nextBacktrackCell(board.data)
+	grid := board.Grid()
+	solved, valid, id, marks := nextGridCell(&grid)
 	if solved {
 		t.Fatal("expected puzzle to be unsolved")
 	}
@@ -132,13 +140,61 @@ func TestNextBacktrackCellChoosesMinimumCandidateCell(t *testing.T) {
 				continue
 			}
 			candidates := candidateSetForPosition(board.data, i, j)
+			if candidates != grid.Candidates(cellID(i, j)) {
+				t.Fatalf("grid candidates of [%d][%d] = %s, want %s", i, j, grid.Candidates(cellID(i, j)).String(), candidates.String())
+			}
 			if candidates.GetCardinality() < best {
-				t.Fatalf("selected cell [%d][%d] had %d candidates, but [%d][%d] had %d", row, col, best, i, j, candidates.GetCardinality())
+				t.Fatalf("selected cell %d had %d candidates, but [%d][%d] had %d", id, best, i, j, candidates.GetCardinality())
 			}
 		}
 	}
 }
 
+func TestBoardSharesGridUnitsAndClones(t *testing.T) {
+	board, err := NewBoard(mustGridFromString(t, "003020600900305001001806400008102900700000008006708200002609500800203009005010300"))
+	if err != nil {
+		t.Fatalf("NewBoard() error = %v", err)
+	}
+	for id := 0; id < CellCount; id++ {
+		cell := board.cells[id]
+		units := cell.CellUnits(board)
+		if len(units) != 3 || !IsCellInCollection(&cell, units[0]) || !IsCellInCollection(&cell, units[1]) || !IsCellInCollection(&cell, units[2]) {
+			t.Fatalf("CellUnits(%d) doesn't contain the cell", id)
+		}
+		if len(peerIDs[id]) != PeerCount || peerIDs[id][0] == id {
+			t.Fatalf("peerIDs[%d] = %v", id, peerIDs[id])
+		}
+	}
+	if allocs := testing.AllocsPerRun(100, func() {
+		_ = board.row(4)
+		_ = board.col(4)
+		_ = board.box(4, 4)
+		_ = board.data[4][4].units(board)
+	}); allocs != 0 {
+		t.Fatalf("units allocated %.0f times, want 0", allocs)
+	}
+
+	units := board.Units()
+	units[0][0], units[0][8] = units[0][8], units[0][0]
+	board.data[4][4].CellUnits(board)[0][0] = nil
+	if board.row(0)[0] != board.data[0][0] || board.row(4)[0] != board.data[4][0] {
+		t.Fatal("Units() and CellUnits() should return copies of the board units")
+	}
+
+	clone := board.clone()
+	clone.data[0][0].Value = 4
+	if board.data[0][0].Value != EmptyCellValue || clone.row(0)[0].Value != 4 || clone.box(0, 0)[0] != clone.data[0][0] {
+		t.Fatal("clone() should own its cells and link its units to them")
+	}
+
+	grid := GridOf(board.Values())
+	grid.Values[1] = grid.Values[2]
+	conflict := grid.Conflict()
+	if conflict == nil || conflict.Unit != RowUnit || conflict.Index != 0 || conflict.Cells[0] != (CellRef{Row: 0, Col: 1}) {
+		t.Fatalf("Conflict() = %+v, want the first row", conflict)
+	}
+}
+
 func TestOrderedStrategiesUseExpectedOrder(t *testing.T) {
 	expected := []StrategyName{
 		NakedQuadsStrategy,
@@ -148,8 +204,18 @@ func TestOrderedStrategiesUseExpectedOrder(t *testing.T) {
 		LockedCandidatesStrategy,
 		XYWingsStrategy,
 		XYZWingsStrategy,
+		WWingsStrategy,
 		XWingsStrategy,
+		SkyscraperStrategy,
+		TwoStringKiteStrategy,
+		EmptyRectangleStrategy,
 		SwordFishStrategy,
+		ColoringStrategy,
+		XYChainsStrategy,
+		JellyfishStrategy,
+		FinnedXWingsStrategy,
+		FinnedSwordFishStrategy,
+		FinnedJellyfishStrategy,
 		HiddenQuadsStrategy,
 		HiddenTripletsStrategy,
 		HiddenPairsStrategy,
@@ -227,6 +293,26 @@ func TestEliminateLockedCandidatesPointingPair(t *testing.T) {
 	}
 }
 
+func TestEliminateLockedCandidatesPointingPairInColumnOfCenterBox(t *testing.T) {
+	board, err := NewBoard(mustGridFromString(t, solvedBoard))
+	if err != nil {
+		t.Fatalf("NewBoard() error = %v", err)
+	}
+
+	// The pair is in box 4, the target is in box 1 of the same stack
+	setCandidates(board, 3, 4, 5, 1)
+	setCandidates(board, 5, 4, 5, 2)
+	setCandidates(board, 0, 4, 5, 6)
+
+	if err := board.eliminateLockedCandidates(); err != nil {
+		t.Fatalf("eliminateLockedCandidates() error = %v", err)
+	}
+
+	if board.data[0][4].Marks.Contains(5) {
+		t.Fatal("pointing pair did not eliminate candidate 5 from the col outside the box")
+	}
+}
+
 func TestEliminateLockedCandidatesClaimingPair(t *testing.T) {
 	board, err := NewBoard(mustGridFromString(t, solvedBoard))
 	if err != nil {
@@ -292,6 +378,424 @@ func TestEliminateSwordFishFindsColumnBasedPattern(t *testing.T) {
 	}
 }
 
+func TestFindFishSearchesEveryDigit(t *testing.T) {
+	board, err := NewBoard(mustGridFromString(t, solvedBoard))
+	if err != nil {
+		t.Fatalf("NewBoard() error = %v", err)
+	}
+
+	// 5 appears twice in row 0 as well, the X-Wing is on 6
+	setCandidates(board, 0, 0, 5, 6)
+	setCandidates(board, 0, 3, 5, 6)
+	setCandidates(board, 3, 0, 6, 7)
+	setCandidates(board, 3, 3, 6, 7)
+	setCandidates(board, 5, 0, 6, 8)
+
+	if err := board.eliminateXWings(); err != nil {
+		t.Fatalf("eliminateXWings() error = %v", err)
+	}
+
+	if board.data[5][0].Marks.Contains(6) {
+		t.Fatal("X-Wing on the second digit of the base row did not eliminate candidate 6")
+	}
+}
+
+func TestEliminateJellyfishFindsRowBasedPattern(t *testing.T) {
+	board, err := NewBoard(mustGridFromString(t, solvedBoard))
+	if err != nil {
+		t.Fatalf("NewBoard() error = %v", err)
+	}
+
+	setCandidates(board, 0, 0, 4, 1)
+	setCandidates(board, 0, 2, 4, 1)
+	setCandidates(board, 2, 2, 4, 2)
+	setCandidates(board, 2, 4, 4, 2)
+	setCandidates(board, 4, 4, 4, 3)
+	setCandidates(board, 4, 6, 4, 3)
+	setCandidates(board, 6, 6, 4, 5)
+	setCandidates(board, 6, 0, 4, 5)
+	setCandidates(board, 8, 0, 4, 9)
+
+	if fishes, _ := FindFish(board, 3); len(fishes) != 0 {
+		t.Fatalf("FindFish(3) = %d fishes, want none", len(fishes))
+	}
+	fishes, err := FindFish(board, 4)
+	if err != nil || len(fishes) != 1 {
+		t.Fatalf("FindFish(4) = %d fishes, %v, want 1", len(fishes), err)
+	}
+	if fish := fishes[0]; fish.Strategy() != JellyfishStrategy || !fish.ByRows || fish.Base != CandidateSetOf(1, 3, 5, 7) {
+		t.Fatalf("FindFish(4) = %+v, want a row-based Jellyfish", fish)
+	}
+	if err := board.eliminateJellyfish(); err != nil {
+		t.Fatalf("eliminateJellyfish() error = %v", err)
+	}
+	if board.data[8][0].Marks.Contains(4) {
+		t.Fatal("Jellyfish did not eliminate candidate 4 from the cover column")
+	}
+	if _, err := FindFish(board, 5); err == nil {
+		t.Fatal("FindFish(5) expected to reject the size")
+	}
+}
+
+func TestEliminateFinnedXWingsUsesFinBox(t *testing.T) {
+	board, err := NewBoard(mustGridFromString(t, solvedBoard))
+	if err != nil {
+		t.Fatalf("NewBoard() error = %v", err)
+	}
+
+	setCandidates(board, 0, 1, 3, 1)
+	setCandidates(board, 0, 5, 3, 2)
+	setCandidates(board, 4, 1, 3, 4)
+	setCandidates(board, 4, 5, 3, 5)
+	setCandidates(board, 4, 4, 3, 6)
+	setCandidates(board, 3, 5, 3, 8)
+	setCandidates(board, 7, 5, 3, 9)
+
+	if err := board.eliminateFinnedXWings(); err != nil {
+		t.Fatalf("eliminateFinnedXWings() error = %v", err)
+	}
+
+	if board.data[3][5].Marks.Contains(3) {
+		t.Fatal("finned X-Wing did not eliminate candidate 3 from the cover col inside the fin box")
+	}
+	if !board.data[7][5].Marks.Contains(3) {
+		t.Fatal("finned X-Wing eliminated candidate 3 from a cell which doesn't see the fin")
+	}
+	if len(board.trace) != 1 || board.trace[0].Strategy != FinnedXWingsStrategy {
+		t.Fatalf("trace = %+v, want a single Finned X Wings deduction", board.trace)
+	}
+	deduction := board.trace[0]
+	if !slices.Equal(deduction.Fins, []CellRef{{Row: 4, Col: 4}}) {
+		t.Fatalf("Fins = %v, want r5c5", deduction.Fins)
+	}
+	if !strings.Contains(deduction.Explain(), "fins r5c5") {
+		t.Fatalf("Explain() = %q, want the fin", deduction.Explain())
+	}
+}
+
+func TestFindFinnedFishReportsSashimi(t *testing.T) {
+	board, err := NewBoard(mustGridFromString(t, solvedBoard))
+	if err != nil {
+		t.Fatalf("NewBoard() error = %v", err)
+	}
+
+	// Without the fin r5c5 row 5 holds 3 only in r5c2, so the X-Wing is degenerate
+	setCandidates(board, 0, 1, 3, 1)
+	setCandidates(board, 0, 5, 3, 2)
+	setCandidates(board, 4, 1, 3, 4)
+	setCandidates(board, 4, 4, 3, 6)
+	setCandidates(board, 3, 5, 3, 8)
+
+	if fishes, _ := FindFish(board, 2); len(fishes) != 0 {
+		t.Fatalf("FindFish(2) = %d fishes, want none", len(fishes))
+	}
+	fishes, err := FindFinnedFish(board, 2)
+	if err != nil || len(fishes) == 0 {
+		t.Fatalf("FindFinnedFish(2) = %d fishes, %v", len(fishes), err)
+	}
+	fish := fishes[0]
+	if !fish.Sashimi || fish.Strategy() != FinnedXWingsStrategy || len(fish.Fins) != 1 || fish.Fins[0] != board.data[4][4] {
+		t.Fatalf("FindFinnedFish(2) = %+v, want a sashimi X-Wing with the fin r5c5", fish)
+	}
+	if err := board.eliminateFinnedXWings(); err != nil {
+		t.Fatalf("eliminateFinnedXWings() error = %v", err)
+	}
+	if board.data[3][5].Marks.Contains(3) {
+		t.Fatal("sashimi X-Wing did not eliminate candidate 3 from r4c6")
+	}
+}
+
+func TestEliminateSkyscrapersUsesBothTops(t *testing.T) {
+	board, err := NewBoard(mustGridFromString(t, solvedBoard))
+	if err != nil {
+		t.Fatalf("NewBoard() error = %v", err)
+	}
+
+	setCandidates(board, 1, 0, 7, 1)
+	setCandidates(board, 1, 4, 7, 2)
+	setCandidates(board, 5, 0, 7, 3)
+	setCandidates(board, 5, 3, 7, 4)
+	setCandidates(board, 0, 3, 7, 5)
+	setCandidates(board, 3, 4, 7, 6)
+
+	if err := board.eliminateSkyscrapers(); err != nil {
+		t.Fatalf("eliminateSkyscrapers() error = %v", err)
+	}
+
+	if board.data[0][3].Marks.Contains(7) || board.data[3][4].Marks.Contains(7) {
+		t.Fatal("Skyscraper did not eliminate candidate 7 from the cells seeing both tops")
+	}
+	if len(board.trace) == 0 || board.trace[0].Strategy != SkyscraperStrategy {
+		t.Fatalf("trace = %+v, want a Skyscraper deduction first", board.trace)
+	}
+}
+
+func TestEliminateTwoStringKitesJoinsStringsInBox(t *testing.T) {
+	board, err := NewBoard(mustGridFromString(t, solvedBoard))
+	if err != nil {
+		t.Fatalf("NewBoard() error = %v", err)
+	}
+
+	setCandidates(board, 0, 1, 4, 1)
+	setCandidates(board, 0, 6, 4, 2)
+	setCandidates(board, 2, 0, 4, 3)
+	setCandidates(board, 6, 0, 4, 5)
+	setCandidates(board, 6, 6, 4, 6)
+
+	if err := board.eliminateTwoStringKites(); err != nil {
+		t.Fatalf("eliminateTwoStringKites() error = %v", err)
+	}
+
+	if board.data[6][6].Marks.Contains(4) {
+		t.Fatal("2-String Kite did not eliminate candidate 4 from the cell seeing both string ends")
+	}
+	if len(board.trace) != 1 || board.trace[0].Strategy != TwoStringKiteStrategy || len(board.trace[0].Pattern) != 4 {
+		t.Fatalf("trace = %+v, want a single 2-String Kite deduction", board.trace)
+	}
+}
+
+func TestEliminateEmptyRectanglesUsesHinge(t *testing.T) {
+	board, err := NewBoard(mustGridFromString(t, solvedBoard))
+	if err != nil {
+		t.Fatalf("NewBoard() error = %v", err)
+	}
+
+	// The candidates of box 1 lie in row 1 and col 1, col 6 is a conjugate pair with its hinge on row 1
+	setCandidates(board, 0, 1, 2, 1)
+	setCandidates(board, 0, 2, 2, 3)
+	setCandidates(board, 1, 0, 2, 4)
+	setCandidates(board, 2, 0, 2, 5)
+	setCandidates(board, 0, 5, 2, 6)
+	setCandidates(board, 6, 5, 2, 7)
+	setCandidates(board, 6, 0, 2, 8)
+	setCandidates(board, 6, 8, 2, 9)
+
+	if err := board.eliminateEmptyRectangles(); err != nil {
+		t.Fatalf("eliminateEmptyRectangles() error = %v", err)
+	}
+
+	if board.data[6][0].Marks.Contains(2) {
+		t.Fatal("Empty Rectangle did not eliminate candidate 2 from r7c1")
+	}
+	if !board.data[6][8].Marks.Contains(2) {
+		t.Fatal("Empty Rectangle eliminated candidate 2 from a cell outside the rectangle col")
+	}
+	if len(board.trace) != 1 || board.trace[0].Strategy != EmptyRectangleStrategy {
+		t.Fatalf("trace = %+v, want a single Empty Rectangle deduction", board.trace)
+	}
+}
+
+func TestEliminateColoringTrapsCellSeeingBothColors(t *testing.T) {
+	board, err := NewBoard(mustGridFromString(t, solvedBoard))
+	if err != nil {
+		t.Fatalf("NewBoard() error = %v", err)
+	}
+
+	// r1c1 - r1c6 - r3c4 - r8c4 are chained by the conjugate pairs of row 1, box 2 and col 4
+	setCandidates(board, 0, 0, 6, 1)
+	setCandidates(board, 0, 5, 6, 2)
+	setCandidates(board, 2, 3, 6, 3)
+	setCandidates(board, 7, 3, 6, 4)
+	setCandidates(board, 7, 0, 6, 5)
+	setCandidates(board, 5, 0, 6, 7)
+	setCandidates(board, 7, 8, 6, 8)
+
+	if err := board.eliminateColoring(); err != nil {
+		t.Fatalf("eliminateColoring() error = %v", err)
+	}
+
+	if board.data[7][0].Marks.Contains(6) {
+		t.Fatal("color trap did not eliminate candidate 6 from r8c1")
+	}
+	if !board.data[5][0].Marks.Contains(6) || !board.data[7][8].Marks.Contains(6) {
+		t.Fatal("color trap eliminated candidate 6 from a cell seeing a single color")
+	}
+	if len(board.trace) != 1 || len(board.trace[0].Clusters) != 1 {
+		t.Fatalf("trace = %+v, want a single deduction with one cluster", board.trace)
+	}
+	expected := ColorCluster{Colors: [2][]CellRef{{{Row: 0, Col: 0}, {Row: 2, Col: 3}}, {{Row: 0, Col: 5}, {Row: 7, Col: 3}}}}
+	cluster := board.trace[0].Clusters[0]
+	if !slices.Equal(cluster.Colors[0], expected.Colors[0]) || !slices.Equal(cluster.Colors[1], expected.Colors[1]) {
+		t.Fatalf("Clusters = %+v, want %+v", cluster, expected)
+	}
+	if explanation := board.trace[0].Explain(); !strings.Contains(explanation, "r1c1, r3c4 against r1c6, r8c4") {
+		t.Fatalf("Explain() = %q, want the colors of the cluster", explanation)
+	}
+}
+
+func TestEliminateColoringWrapsColorSeeingItself(t *testing.T) {
+	board, err := NewBoard(mustGridFromString(t, solvedBoard))
+	if err != nil {
+		t.Fatalf("NewBoard() error = %v", err)
+	}
+
+	// r1c1 and r2c2 get the same color and share box 1
+	setCandidates(board, 0, 0, 6, 1)
+	setCandidates(board, 0, 4, 6, 2)
+	setCandidates(board, 3, 4, 6, 3)
+	setCandidates(board, 3, 1, 6, 4)
+	setCandidates(board, 1, 1, 6, 5)
+	setCandidates(board, 2, 2, 6, 7)
+
+	if err := board.eliminateColoring(); err != nil {
+		t.Fatalf("eliminateColoring() error = %v", err)
+	}
+
+	for _, ref := range []CellRef{{Row: 0, Col: 0}, {Row: 3, Col: 4}, {Row: 1, Col: 1}} {
+		if board.data[ref.Row][ref.Col].Marks.Contains(6) {
+			t.Fatalf("color wrap did not eliminate candidate 6 from %s", ref)
+		}
+	}
+	for _, ref := range []CellRef{{Row: 0, Col: 4}, {Row: 3, Col: 1}, {Row: 2, Col: 2}} {
+		if !board.data[ref.Row][ref.Col].Marks.Contains(6) {
+			t.Fatalf("color wrap eliminated candidate 6 from %s", ref)
+		}
+	}
+}
+
+func TestEliminateColoringJoinsClusters(t *testing.T) {
+	board, err := NewBoard(mustGridFromString(t, solvedBoard))
+	if err != nil {
+		t.Fatalf("NewBoard() error = %v", err)
+	}
+
+	// Row 1 and row 5 are separate clusters, r1c2 and r5c2 share col 2 so r1c8 or r5c7 holds 3
+	setCandidates(board, 0, 1, 3, 1)
+	setCandidates(board, 0, 7, 3, 2)
+	setCandidates(board, 4, 1, 3, 4)
+	setCandidates(board, 4, 6, 3, 5)
+	setCandidates(board, 1, 6, 3, 6)
+	setCandidates(board, 2, 8, 3, 7)
+	setCandidates(board, 7, 1, 3, 8)
+	setCandidates(board, 8, 6, 3, 9)
+
+	if err := board.eliminateColoring(); err != nil {
+		t.Fatalf("eliminateColoring() error = %v", err)
+	}
+
+	if board.data[1][6].Marks.Contains(3) {
+		t.Fatal("multi-coloring did not eliminate candidate 3 from r2c7")
+	}
+	for _, ref := range []CellRef{{Row: 2, Col: 8}, {Row: 7, Col: 1}, {Row: 8, Col: 6}} {
+		if !board.data[ref.Row][ref.Col].Marks.Contains(3) {
+			t.Fatalf("multi-coloring eliminated candidate 3 from %s", ref)
+		}
+	}
+	if len(board.trace) != 1 || len(board.trace[0].Clusters) != 2 {
+		t.Fatalf("trace = %+v, want a single deduction with two clusters", board.trace)
+	}
+}
+
+func TestEliminateWWingsUsesStrongLink(t *testing.T) {
+	board, err := NewBoard(mustGridFromString(t, solvedBoard))
+	if err != nil {
+		t.Fatalf("NewBoard() error = %v", err)
+	}
+
+	// r1c1 and r5c5 hold {1,2}, row 9 links 1 between r9c1 and r9c5 so one of the wings holds 2
+	setCandidates(board, 0, 0, 1, 2)
+	setCandidates(board, 4, 4, 1, 2)
+	setCandidates(board, 8, 0, 1, 3)
+	setCandidates(board, 8, 4, 1, 4)
+	setCandidates(board, 0, 4, 2, 5)
+	setCandidates(board, 4, 0, 2, 6)
+
+	if err := board.eliminateWWings(); err != nil {
+		t.Fatalf("eliminateWWings() error = %v", err)
+	}
+
+	if board.data[0][4].Marks.Contains(2) || board.data[4][0].Marks.Contains(2) {
+		t.Fatal("W Wing did not eliminate candidate 2 from the cells seeing both wings")
+	}
+	if len(board.trace) != 1 || board.trace[0].Strategy != WWingsStrategy || len(board.trace[0].Pattern) != 4 {
+		t.Fatalf("trace = %+v, want a single W Wing deduction over four cells", board.trace)
+	}
+}
+
+func TestEliminateXYChainsRespectsMaxChainLength(t *testing.T) {
+	newChainBoard := func() *Board {
+		board, err := NewBoard(mustGridFromString(t, solvedBoard))
+		if err != nil {
+			t.Fatalf("NewBoard() error = %v", err)
+		}
+		// r1c1 -2- r1c6 -3- r5c6 -4- r5c9, so r1c1 or r5c9 holds 1
+		setCandidates(board, 0, 0, 1, 2)
+		setCandidates(board, 0, 5, 2, 3)
+		setCandidates(board, 4, 5, 3, 4)
+		setCandidates(board, 4, 8, 4, 1)
+		setCandidates(board, 0, 8, 1, 5)
+		setCandidates(board, 4, 0, 1, 6)
+		return board
+	}
+
+	short, err := New(WithStrategies(XYChainsStrategy), WithMaxChainLength(3))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if short.MaxChainLength() != 3 {
+		t.Fatalf("MaxChainLength() = %d, want 3", short.MaxChainLength())
+	}
+	board := newChainBoard()
+	if changed, err := short.strategies[0].Apply(board); err != nil || changed {
+		t.Fatalf("Apply() with chains of 3 cells = %v, %v, want no change", changed, err)
+	}
+
+	board = newChainBoard()
+	if err := board.eliminateXYChains(); err != nil {
+		t.Fatalf("eliminateXYChains() error = %v", err)
+	}
+	if board.data[0][8].Marks.Contains(1) || board.data[4][0].Marks.Contains(1) {
+		t.Fatal("XY Chain did not eliminate candidate 1 from the cells seeing both ends")
+	}
+	if len(board.trace) == 0 || board.trace[0].Strategy != XYChainsStrategy || len(board.trace[0].Pattern) != 4 {
+		t.Fatalf("trace = %+v, want an XY Chain deduction over four cells first", board.trace)
+	}
+
+	if _, err := New(WithMaxChainLength(2)); err == nil {
+		t.Fatal("New() with chains of 2 cells error = nil, want an error")
+	}
+	if err := EliminateXYChains(board, 2); err == nil {
+		t.Fatal("EliminateXYChains() with chains of 2 cells error = nil, want an error")
+	}
+}
+
+func TestAdvancedEliminationsAgreeWithTop95Solutions(t *testing.T) {
+	boards, err := ParseFile("../../data/top95.txt")
+	if err != nil {
+		t.Fatalf("ParseFile() error = %v", err)
+	}
+	names := []StrategyName{
+		XWingsStrategy, SwordFishStrategy, JellyfishStrategy,
+		FinnedXWingsStrategy, FinnedSwordFishStrategy, FinnedJellyfishStrategy,
+		SkyscraperStrategy, TwoStringKiteStrategy, EmptyRectangleStrategy, ColoringStrategy,
+		WWingsStrategy, XYChainsStrategy,
+	}
+	s, err := New(WithStrategies(append([]StrategyName{HiddenSingleStrategy, LockedCandidatesStrategy}, names...)...), WithoutBacktracking())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	advanced := 0
+	for i, board := range boards {
+		solution, _, _ := SolveGrid(context.Background(), board.Grid())
+		response := s.Solve(board)
+		for _, deduction := range response.SolveTrace {
+			if !slices.Contains(names, deduction.Strategy) {
+				continue
+			}
+			advanced++
+			for _, elimination := range deduction.Eliminations {
+				id := cellID(elimination.Cell.Row, elimination.Cell.Col)
+				if elimination.Marks.Contains(int(solution.Values[id])) {
+					t.Fatalf("%s on board %d eliminated the solution digit of %s", deduction.Strategy, i, elimination.Cell)
+				}
+			}
+		}
+	}
+	if advanced == 0 {
+		t.Fatal("no advanced deduction found on top95")
+	}
+}
+
 func TestSolveTop95Board61DoesNotFailInXYWing(t *testing.T) {
 	board, err := NewBoard(mustGridFromString(t, "1.....3.8.6.4..............2.3.1...........758.........7.5...6.....8.2...4......."))
 	if err != nil {
@@ -307,6 +811,998 @@ func TestSolveTop95Board61DoesNotFailInXYWing(t *testing.T) {
 	}
 }
 
+func TestSolveTraceReplaysSolution(t *testing.T) {
+	input := "1.....3.8.6.4..............2.3.1...........758.........7.5...6.....8.2...4......."
+	board, err := NewBoard(mustGridFromString(t, input))
+	if err != nil {
+		t.Fatalf("NewBoard() error = %v", err)
+	}
+
+	response := board.Solve()
+	if !response.IsSolved {
+		t.Fatalf("Solve() did not solve the puzzle: %v", response.Error)
+	}
+	if len(response.SolveTrace) == 0 {
+		t.Fatal("Solve() returned an empty trace")
+	}
+
+	grid := mustGridFromString(t, input)
+	for i, deduction := range response.SolveTrace {
+		if len(deduction.Placements) == 0 && len(deduction.Eliminations) == 0 {
+			t.Fatalf("trace[%d] %q has neither placements nor eliminations", i, deduction.Strategy)
+		}
+		if deduction.Strategy != BackTrackingStrategy && len(deduction.Pattern) == 0 {
+			t.Fatalf("trace[%d] %q has no pattern cells", i, deduction.Strategy)
+		}
+		for _, placement := range deduction.Placements {
+			if grid[placement.Cell.Row][placement.Cell.Col] != EmptyCellValue {
+				t.Fatalf("trace[%d] places %s twice", i, placement.Cell)
+			}
+			grid[placement.Cell.Row][placement.Cell.Col] = placement.Value
+		}
+	}
+
+	replayed, err := NewBoard(grid)
+	if err != nil {
+		t.Fatalf("NewBoard(replayed) error = %v", err)
+	}
+	if replayed.getState() != response.Solution {
+		t.Fatalf("replayed trace does not match the solution:\n%s", replayed.getState())
+	}
+}
+
+func TestHintDoesNotChangeBoard(t *testing.T) {
+	board, err := NewBoard(mustGridFromString(t, "003020600900305001001806400008102900700000008006708200002609500800203009005010300"))
+	if err != nil {
+		t.Fatalf("NewBoard() error = %v", err)
+	}
+	before := board.getState()
+
+	hint, err := board.Hint()
+	if err != nil {
+		t.Fatalf("Hint() error = %v", err)
+	}
+	if board.getState() != before || len(board.trace) != 0 {
+		t.Fatal("Hint() changed the board")
+	}
+	if hint.Strategy != NakedSingleStrategy && hint.Strategy != HiddenSingleStrategy {
+		t.Fatalf("Hint() strategy = %q, want a single", hint.Strategy)
+	}
+	if len(hint.Pattern) == 0 || len(hint.Targets) == 0 || hint.Explanation == "" {
+		t.Fatalf("Hint() returned an incomplete hint: %+v", hint)
+	}
+
+	solution := mustGridFromString(t, solvedBoard)
+	for _, placement := range hint.Placements {
+		if solution[placement.Cell.Row][placement.Cell.Col] != placement.Value {
+			t.Fatalf("Hint() placed %d in %s, want %d", placement.Value, placement.Cell, solution[placement.Cell.Row][placement.Cell.Col])
+		}
+	}
+}
+
+func TestHintExplainsLockedCandidates(t *testing.T) {
+	board, err := NewBoard(mustGridFromString(t, solvedBoard))
+	if err != nil {
+		t.Fatalf("NewBoard() error = %v", err)
+	}
+	clone := board.clone()
+	setCandidates(clone, 0, 0, 5, 1)
+	setCandidates(clone, 0, 1, 5, 2)
+	setCandidates(clone, 0, 3, 5, 6)
+
+	if err := clone.eliminateLockedCandidates(); err != nil {
+		t.Fatalf("eliminateLockedCandidates() error = %v", err)
+	}
+	if len(clone.trace) != 1 {
+		t.Fatalf("recorded %d deductions, want 1", len(clone.trace))
+	}
+	hint := newHint(clone.trace[0])
+	if hint.Strategy != LockedCandidatesStrategy {
+		t.Fatalf("strategy = %q, want %q", hint.Strategy, LockedCandidatesStrategy)
+	}
+	if len(hint.Targets) != 1 || hint.Targets[0] != (CellRef{Row: 0, Col: 3}) {
+		t.Fatalf("targets = %v, want [r1c4]", hint.Targets)
+	}
+	want := "Within a unit 5 is confined to r1c1, r1c2, which also share another unit, so 5 is removed from r1c4."
+	if hint.Explanation != want {
+		t.Fatalf("explanation = %q, want %q", hint.Explanation, want)
+	}
+}
+
+func TestCountSolutionsDetectsMultipleSolutions(t *testing.T) {
+	unique, err := NewBoard(mustGridFromString(t, "003020600900305001001806400008102900700000008006708200002609500800203009005010300"))
+	if err != nil {
+		t.Fatalf("NewBoard() error = %v", err)
+	}
+	if count := CountSolutions(unique, 2); count != 1 {
+		t.Fatalf("CountSolutions(unique) = %d, want 1", count)
+	}
+
+	// Blanking a rectangle of swappable values produces exactly two solutions
+	ambiguous, err := NewBoard(mustGridFromString(t, "48392165796734582125187649354813297672956413813679824537268951481425..6969541..82"))
+	if err != nil {
+		t.Fatalf("NewBoard() error = %v", err)
+	}
+	if count := CountSolutions(ambiguous, 10); count != 2 {
+		t.Fatalf("CountSolutions(ambiguous) = %d, want 2", count)
+	}
+	if HasUniqueSolution(ambiguous) {
+		t.Fatal("HasUniqueSolution() reported an ambiguous board as unique")
+	}
+
+	response := ambiguous.SolveUnique()
+	if response.Error == nil || response.IsSolved {
+		t.Fatal("SolveUnique() expected to reject a board with multiple solutions")
+	}
+}
+
+func TestParseFileRejectNonUnique(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "boards.txt")
+	content := "" +
+		"003020600900305001001806400008102900700000008006708200002609500800203009005010300\n" +
+		"48392165796734582125187649354813297672956413813679824537268951481425..6969541..82\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	boards, err := ParseFile(path, RejectNonUnique())
+	if err != nil {
+		t.Fatalf("ParseFile() error = %v", err)
+	}
+	if len(boards) != 1 {
+		t.Fatalf("ParseFile() parsed %d boards, want 1", len(boards))
+	}
+}
+
+func TestRateUsesStrategiesInsteadOfGivens(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          string
+		difficulty     Difficulty
+		clueDifficulty string
+		hardest        StrategyName
+	}{
+		{
+			// easy50 #1, the default pipeline tries Naked Quads before the singles finish it
+			name:           "singles only",
+			input:          "003020600900305001001806400008102900700000008006708200002609500800203009005010300",
+			difficulty:     Easy,
+			clueDifficulty: "Medium",
+			hardest:        NakedSingleStrategy,
+		},
+		{
+			name:           "17 clues without advanced strategies",
+			input:          "4.....8.5.3..........7......2.....6.....8.4......1.......6.3.7.5..2.....1.4......",
+			difficulty:     Hard,
+			clueDifficulty: "Evil",
+			hardest:        LockedCandidatesStrategy,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			board, err := NewBoard(mustGridFromString(t, tt.input))
+			if err != nil {
+				t.Fatalf("NewBoard() error = %v", err)
+			}
+			rating, err := Rate(board)
+			if err != nil {
+				t.Fatalf("Rate() error = %v", err)
+			}
+			if rating.Difficulty != tt.difficulty || rating.HardestStrategy != tt.hardest {
+				t.Fatalf("Rate() = %s by %q, want %s by %q", Levels[rating.Difficulty], rating.HardestStrategy, Levels[tt.difficulty], tt.hardest)
+			}
+
+			graded := board.clone().SolveGraded()
+			if graded.Difficulty != Levels[tt.difficulty] || graded.ClueDifficulty != tt.clueDifficulty {
+				t.Fatalf("SolveGraded() difficulty = %s/%s, want %s/%s", graded.Difficulty, graded.ClueDifficulty, Levels[tt.difficulty], tt.clueDifficulty)
+			}
+
+			response := board.Solve()
+			if response.Difficulty != Levels[tt.difficulty] || response.ClueDifficulty != tt.clueDifficulty {
+				t.Fatalf("Solve() difficulty = %s/%s, want %s/%s", response.Difficulty, response.ClueDifficulty, Levels[tt.difficulty], tt.clueDifficulty)
+			}
+			if response.Rating == nil || response.Rating.HardestStrategy != tt.hardest {
+				t.Fatalf("Solve() rating = %+v, want the hardest strategy %q", response.Rating, tt.hardest)
+			}
+		})
+	}
+}
+
+func TestRateReportsUnavoidableBackTracking(t *testing.T) {
+	board, err := NewBoard(mustGridFromString(t, "6.2.5.........3.4..........43...8....1....2........7..5..27...........81...6....."))
+	if err != nil {
+		t.Fatalf("NewBoard() error = %v", err)
+	}
+	rating, err := Rate(board)
+	if err != nil {
+		t.Fatalf("Rate() error = %v", err)
+	}
+	if !rating.BackTrackingUsed || rating.Difficulty != Evil {
+		t.Fatalf("Rate() = %+v, want Evil with backtracking", rating)
+	}
+	if rating.StrategyCounts[NakedSingleStrategy] == 0 {
+		t.Fatal("Rate() did not count the naked singles")
+	}
+}
+
+func TestRateGradesTheHardestLogicAsEvil(t *testing.T) {
+	board, err := NewBoard(mustGridFromString(t, "....7..8...6...5...2...3.61.1...7..2..8..534.2..9.......2......58...6.3.4...1...."))
+	if err != nil {
+		t.Fatalf("NewBoard() error = %v", err)
+	}
+	rating, err := Rate(board)
+	if err != nil {
+		t.Fatalf("Rate() error = %v", err)
+	}
+	if rating.BackTrackingUsed || rating.HardestStrategy != NakedQuadsStrategy || rating.Difficulty != Evil {
+		t.Fatalf("Rate() = %+v, want Evil by Naked Quads without backtracking", rating)
+	}
+}
+
+func TestSolveContextReturnsCanceledErrorWithPartialGrid(t *testing.T) {
+	board, err := NewBoard(mustGridFromString(t, "003020600900305001001806400008102900700000008006708200002609500800203009005010300"))
+	if err != nil {
+		t.Fatalf("NewBoard() error = %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	response := board.SolveContext(ctx)
+	var canceledErr *CanceledError
+	if !errors.As(response.Error, &canceledErr) {
+		t.Fatalf("SolveContext() error = %v, want *CanceledError", response.Error)
+	}
+	if !errors.Is(response.Error, context.Canceled) {
+		t.Fatalf("SolveContext() error = %v, want to wrap context.Canceled", response.Error)
+	}
+	if response.IsSolved || response.Solution != response.InitialState {
+		t.Fatal("SolveContext() should return the partial grid of a canceled solve")
+	}
+}
+
+func TestBackTrackContextStopsOnDeadline(t *testing.T) {
+	board, err := NewBoard(mustGridFromString(t, "4.....8.5.3..........7......2.....6.....8.4......1.......6.3.7.5..2.....1.4......"))
+	if err != nil {
+		t.Fatalf("NewBoard() error = %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+
+	solved, _, err := BackTrackContext(ctx, CloneData(board.data))
+	if solved {
+		t.Fatal("BackTrackContext() solved the board after the deadline")
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("BackTrackContext() error = %v, want context.DeadlineExceeded", err)
+	}
+	if board.getState() != board.initialState {
+		t.Fatal("BackTrackContext() changed the board")
+	}
+}
+
+func TestSolveGridAgreesWithBackTrackOnTop95(t *testing.T) {
+	boards, err := ParseFile("../../data/top95.txt")
+	if err != nil {
+		t.Fatalf("ParseFile() error = %v", err)
+	}
+	for i, board := range boards {
+		solution, ok, err := SolveGrid(context.Background(), board.Grid())
+		if err != nil || !ok {
+			t.Fatalf("SolveGrid(board %d) = %t, %v", i, ok, err)
+		}
+		for id, given := range board.Grid().Values {
+			if given != EmptyCellValue && solution.Values[id] != given {
+				t.Fatalf("SolveGrid(board %d) changed the given of cell %d", i, id)
+			}
+			if solution.Values[id] == EmptyCellValue {
+				t.Fatalf("SolveGrid(board %d) left cell %d empty", i, id)
+			}
+		}
+		if conflict := solution.Conflict(); conflict != nil {
+			t.Fatalf("SolveGrid(board %d) conflict = %v", i, conflict)
+		}
+		if count := CountGridSolutions(board.Grid(), 2); count != 1 {
+			t.Fatalf("CountGridSolutions(board %d) = %d, want 1", i, count)
+		}
+		if i < 5 {
+			ok, expected := BackTrack(CloneData(board.data))
+			if !ok || gridOfData(expected).Values != solution.Values {
+				t.Fatalf("SolveGrid(board %d) disagrees with BackTrack()", i)
+			}
+		}
+	}
+
+	var conflicting Grid
+	conflicting.Values[0], conflicting.Values[1] = 5, 5
+	if _, ok, _ := SolveGrid(context.Background(), conflicting); ok || CountGridSolutions(conflicting, 2) != 0 {
+		t.Fatal("SolveGrid() solved a grid with a conflict")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var canceledErr *CanceledError
+	if _, _, err := SolveGrid(ctx, Grid{}); !errors.As(err, &canceledErr) {
+		t.Fatalf("SolveGrid() with a canceled context error = %v, want *CanceledError", err)
+	}
+}
+
+func TestBackendsAgreeOnTop95(t *testing.T) {
+	boards, err := ParseFile("../../data/top95.txt")
+	if err != nil {
+		t.Fatalf("ParseFile() error = %v", err)
+	}
+	dlx, err := New(WithStrategies(), WithBackend(DLXBackend))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	mrv, _ := New(WithBackend(BackTrackBackend))
+	for i, board := range boards[:10] {
+		expected, _, _ := SolveGrid(context.Background(), board.Grid())
+		solution, ok, err := classicDLX.Solve(context.Background(), board.Grid())
+		if err != nil || !ok || solution.Values != expected.Values {
+			t.Fatalf("DLX.Solve(board %d) = %t, %v, want the bitboard solution", i, ok, err)
+		}
+		if count := dlx.CountSolutions(board, 2); count != 1 {
+			t.Fatalf("CountSolutions(board %d) with DLX = %d, want 1", i, count)
+		}
+		if count := mrv.CountSolutions(board, 2); i < 3 && count != 1 {
+			t.Fatalf("CountSolutions(board %d) with MRV = %d, want 1", i, count)
+		}
+	}
+
+	response := dlx.Solve(boards[0])
+	if !response.IsSolved || !response.BackTrackingUsed {
+		t.Fatalf("Solve() with DLX = solved %t, backtracking %t", response.IsSolved, response.BackTrackingUsed)
+	}
+	if _, err := ParseBackend("Dancing"); err == nil {
+		t.Fatal("ParseBackend() expected to reject an unknown name")
+	}
+	if backend, err := ParseBackend("DLX"); err != nil || backend != DLXBackend {
+		t.Fatalf("ParseBackend(DLX) = %s, %v", backend, err)
+	}
+}
+
+func TestDLXEnumeratesSolutions(t *testing.T) {
+	ambiguous := GridOf(mustGridFromString(t, "48392165796734582125187649354813297672956413813679824537268951481425..6969541..82"))
+	solutions := make([]Grid, 0)
+	err := classicDLX.Enumerate(context.Background(), ambiguous, func(solution Grid) bool {
+		solutions = append(solutions, solution)
+		return true
+	})
+	if err != nil || len(solutions) != 2 || solutions[0].Values == solutions[1].Values {
+		t.Fatalf("Enumerate() = %d solutions, %v, want 2 different solutions", len(solutions), err)
+	}
+	for _, solution := range solutions {
+		if solution.Conflict() != nil {
+			t.Fatalf("Enumerate() returned an invalid solution %s", GridString(solution.Matrix()))
+		}
+	}
+	if count := classicDLX.Count(ambiguous, 10); count != 2 {
+		t.Fatalf("Count() = %d, want 2", count)
+	}
+	if count := classicDLX.Count(ambiguous, 1); count != 1 {
+		t.Fatalf("Count() with limit 1 = %d, want 1", count)
+	}
+
+	var conflicting Grid
+	conflicting.Values[0], conflicting.Values[1] = 5, 5
+	if count := classicDLX.Count(conflicting, 2); count != 0 {
+		t.Fatalf("Count() of a conflicting grid = %d, want 0", count)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := classicDLX.Solve(ctx, Grid{}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Solve() with a canceled context error = %v, want context.Canceled", err)
+	}
+}
+
+func TestDLXSolvesVariants(t *testing.T) {
+	// Each region is a broken diagonal, so a solution exists (e.g. row + col mod 9) but no box is a unit
+	regions := make([][]int, BoardSize)
+	for region := 0; region < BoardSize; region++ {
+		for row := 0; row < BoardSize; row++ {
+			regions[region] = append(regions[region], cellID(row, (row+region)%BoardSize))
+		}
+	}
+	tests := []struct {
+		name    string
+		variant Variant
+		units   [][]int
+	}{
+		{name: "diagonals", variant: Variant{Diagonals: true}, units: [][]int{
+			{0, 10, 20, 30, 40, 50, 60, 70, 80},
+			{8, 16, 24, 32, 40, 48, 56, 64, 72},
+		}},
+		{name: "irregular regions", variant: Variant{Regions: regions}, units: regions},
+		{name: "extra window", variant: Variant{Extra: [][]int{{10, 11, 12, 19, 20, 21, 28, 29, 30}}}, units: [][]int{
+			{10, 11, 12, 19, 20, 21, 28, 29, 30},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dlx, err := NewDLX(tt.variant)
+			if err != nil {
+				t.Fatalf("NewDLX() error = %v", err)
+			}
+			solution, ok, err := dlx.Solve(context.Background(), Grid{})
+			if err != nil || !ok {
+				t.Fatalf("Solve() = %t, %v", ok, err)
+			}
+			for _, unit := range append(tt.units, unitIDs[0][:], unitIDs[BoardSize][:]) {
+				var seen CandidateSet
+				for _, id := range unit {
+					seen = seen.Add(int(solution.Values[id]))
+				}
+				if seen != Digits {
+					t.Fatalf("unit %v of %s doesn't hold every digit", unit, GridString(solution.Matrix()))
+				}
+			}
+		})
+	}
+
+	if _, err := NewDLX(Variant{Regions: regions[:8]}); err == nil {
+		t.Fatal("NewDLX() expected to reject eight regions")
+	}
+	overlapping := append([][]int{regions[1]}, regions[1:]...)
+	if _, err := NewDLX(Variant{Regions: overlapping}); err == nil {
+		t.Fatal("NewDLX() expected to reject overlapping regions")
+	}
+}
+
+type removeMarkStrategy struct {
+	row  int
+	col  int
+	mark int
+}
+
+func (s removeMarkStrategy) Name() StrategyName {
+	return "Remove Mark"
+}
+
+func (s removeMarkStrategy) Apply(board *Board) (bool, error) {
+	cell := board.Cell(s.row, s.col)
+	if cell.IsSolved() || !cell.Marks.Contains(s.mark) {
+		return false, nil
+	}
+	cell.Marks = cell.Marks.AndNot(CandidateSetOf(s.mark))
+	return true, nil
+}
+
+func TestSolverRunsCustomPipeline(t *testing.T) {
+	if _, err := New(WithStrategies("Unknown Strategy")); err == nil {
+		t.Fatal("New() expected to reject an unknown strategy name")
+	}
+
+	custom := removeMarkStrategy{row: 0, col: 1, mark: 1}
+	s, err := New(WithStrategies(HiddenSingleStrategy), WithStrategy(custom), WithoutBacktracking())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if names := s.Strategies(); len(names) != 2 || names[0] != HiddenSingleStrategy || names[1] != custom.Name() {
+		t.Fatalf("Strategies() = %v", names)
+	}
+
+	board, err := NewBoard(mustGridFromString(t, "4.....8.5.3..........7......2.....6.....8.4......1.......6.3.7.5..2.....1.4......"))
+	if err != nil {
+		t.Fatalf("NewBoard() error = %v", err)
+	}
+	response := s.Solve(board)
+	if response.IsSolved || response.BackTrackingUsed {
+		t.Fatal("Solve() without backtracking should stop when the pipeline stalls")
+	}
+	found := false
+	for _, deduction := range response.SolveTrace {
+		if deduction.Strategy == custom.Name() {
+			found = len(deduction.Eliminations) == 1 && deduction.Eliminations[0].Marks == CandidateSetOf(1)
+		}
+	}
+	if !found {
+		t.Fatal("custom strategy elimination was not recorded in the trace")
+	}
+}
+
+func TestSolverWithDerivesVariants(t *testing.T) {
+	custom := removeMarkStrategy{row: 0, col: 1, mark: 1}
+	s, err := New(WithStrategy(custom), WithBackend(DLXBackend), WithMaxChainLength(4))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	derived, err := s.With(WithoutBacktracking())
+	if err != nil {
+		t.Fatalf("With() error = %v", err)
+	}
+	if !slices.Equal(derived.Strategies(), s.Strategies()) || derived.Backend() != DLXBackend || derived.MaxChainLength() != 4 {
+		t.Fatalf("With() = %v, %s, %d, want the pipeline, backend and max chain length of the solver",
+			derived.Strategies(), derived.Backend(), derived.MaxChainLength())
+	}
+	if derived.backtrack || !s.backtrack {
+		t.Fatal("With() should change the derived solver only")
+	}
+	if _, err := s.With(WithMaxChainLength(2)); err == nil {
+		t.Fatal("With() expected to reject a too short max chain length")
+	}
+}
+
+func TestCountSolutionsContextStopsEveryBackend(t *testing.T) {
+	var empty Grid
+	for _, backend := range []Backend{BitBoardBackend, BackTrackBackend, DLXBackend} {
+		counter, err := New(WithBackend(backend))
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		board, err := newBoard(empty.Matrix(), 0)
+		if err != nil {
+			t.Fatalf("newBoard() error = %v", err)
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		var canceledErr *CanceledError
+		if _, err := counter.CountSolutionsContext(ctx, board, 1000); !errors.As(err, &canceledErr) {
+			t.Fatalf("%s CountSolutionsContext() with a canceled context error = %v, want *CanceledError", backend, err)
+		}
+		if count, err := counter.CountSolutionsContext(context.Background(), board, 3); err != nil || count != 3 {
+			t.Fatalf("%s CountSolutionsContext() = %d, %v, want 3", backend, count, err)
+		}
+	}
+}
+
+func TestParseDifficulty(t *testing.T) {
+	if difficulty, err := ParseDifficulty("expert"); err != nil || difficulty != Expert {
+		t.Fatalf("ParseDifficulty(expert) = %s, %v", Levels[difficulty], err)
+	}
+	if _, err := ParseDifficulty("impossible"); err == nil {
+		t.Fatal("ParseDifficulty() expected to reject an unknown name")
+	}
+}
+
+func TestRefStrings(t *testing.T) {
+	if cells := RefStrings([]CellRef{{Row: 0, Col: 8}, {Row: 4, Col: 2}}); !slices.Equal(cells, []string{"r1c9", "r5c3"}) {
+		t.Fatalf("RefStrings() = %v", cells)
+	}
+}
+
+func TestRegisterStrategyRejectsDuplicates(t *testing.T) {
+	if err := RegisterStrategy(strategyFunc{name: NakedPairsStrategy}); err == nil {
+		t.Fatal("RegisterStrategy() expected to reject a duplicate name")
+	}
+	strategy, ok := LookupStrategy(SwordFishStrategy)
+	if !ok || strategy.Name() != SwordFishStrategy {
+		t.Fatalf("LookupStrategy() = %v, %t", strategy, ok)
+	}
+}
+
+func TestSolveLogicalReportsStall(t *testing.T) {
+	board, err := NewBoard(mustGridFromString(t, "6.2.5.........3.4..........43...8....1....2........7..5..27...........81...6....."))
+	if err != nil {
+		t.Fatalf("NewBoard() error = %v", err)
+	}
+
+	response := board.SolveLogical()
+	if response.IsSolved || response.BackTrackingUsed {
+		t.Fatal("SolveLogical() should stop without guessing")
+	}
+	if response.Stall == nil {
+		t.Fatal("SolveLogical() did not report the stall")
+	}
+	if len(response.Stall.OpenCells) != board.emptyCells() {
+		t.Fatalf("open cells = %d, want %d", len(response.Stall.OpenCells), board.emptyCells())
+	}
+	for _, ref := range response.Stall.OpenCells {
+		marks := response.Stall.Marks[ref.Row][ref.Col]
+		if marks.GetCardinality() < 2 {
+			t.Fatalf("open cell %s has marks %s, want at least two", ref, marks)
+		}
+	}
+	if len(response.Stall.StrategiesTried) != len(orderedStrategies) {
+		t.Fatalf("strategies tried = %v", response.Stall.StrategiesTried)
+	}
+}
+
+func TestCandidateFormatsRoundTrip(t *testing.T) {
+	board, err := NewBoard(mustGridFromString(t, "6.2.5.........3.4..........43...8....1....2........7..5..27...........81...6....."))
+	if err != nil {
+		t.Fatalf("NewBoard() error = %v", err)
+	}
+	response := board.SolveLogical()
+	if response.Stall == nil {
+		t.Fatal("SolveLogical() did not report the stall")
+	}
+
+	for name, input := range map[string]string{
+		"candidate string": board.CandidateString(),
+		"pencil marks":     response.Stall.Candidates,
+	} {
+		t.Run(name, func(t *testing.T) {
+			loaded, err := ParseCandidates(input)
+			if err != nil {
+				t.Fatalf("ParseCandidates() error = %v", err)
+			}
+			if loaded.CandidateString() != board.CandidateString() {
+				t.Fatal("loaded candidates differ from the saved ones")
+			}
+			if err := loaded.initializeCandidates(); err != nil {
+				t.Fatalf("initializeCandidates() error = %v", err)
+			}
+			if loaded.CandidateString() != board.CandidateString() {
+				t.Fatal("initializing the candidates lost the narrowed marks")
+			}
+		})
+	}
+}
+
+func TestNewBoardFromCandidatesAllowsFewSolvedCells(t *testing.T) {
+	var marks [BoardSize][BoardSize]CandidateSet
+	for i := range marks {
+		for j := range marks[i] {
+			marks[i][j] = Digits
+		}
+	}
+	marks[0][0] = CandidateSetOf(5)
+	marks[0][1] = CandidateSetOf(1, 2)
+
+	board, err := NewBoardFromCandidates(marks)
+	if err != nil {
+		t.Fatalf("NewBoardFromCandidates() error = %v", err)
+	}
+	if givens, _ := board.GetGivensAndBackTrack(); givens != 1 {
+		t.Fatalf("givens = %d, want 1", givens)
+	}
+	if err := board.initializeCandidates(); err != nil {
+		t.Fatalf("initializeCandidates() error = %v", err)
+	}
+	if board.data[0][1].Marks != CandidateSetOf(1, 2) || board.data[0][2].Marks.Contains(5) {
+		t.Fatalf("marks = %s and %s, want the loaded marks without the solved peer", board.data[0][1].Marks, board.data[0][2].Marks)
+	}
+}
+
+func TestParseCandidatesRejectsInvalidGrids(t *testing.T) {
+	if _, err := ParseCandidates("| 1 2 3 |\n"); err == nil {
+		t.Fatal("ParseCandidates() expected to reject a grid with missing rows")
+	}
+	if _, err := ParseCandidates(strings.Repeat("x", CandidateStringLength)); err == nil {
+		t.Fatal("ParseCandidates() expected to reject invalid characters")
+	}
+}
+
+func TestNewBoardReturnsStructuredErrors(t *testing.T) {
+	conflicting := mustGridFromString(t, "003020600900305001001806400008102900700000008006708200002609500800203009005010300")
+	conflicting[0][0] = 6
+
+	_, err := NewBoard(conflicting)
+	var conflict *ConflictError
+	if !errors.As(err, &conflict) {
+		t.Fatalf("NewBoard() error = %v, want *ConflictError", err)
+	}
+	if conflict.Unit != RowUnit || conflict.Index != 0 || conflict.Value != 6 {
+		t.Fatalf("conflict = %+v, want 6 twice in row 0", conflict)
+	}
+	if len(conflict.Cells) != 2 || conflict.Cells[0] != (CellRef{Row: 0, Col: 0}) || conflict.Cells[1] != (CellRef{Row: 0, Col: 6}) {
+		t.Fatalf("conflict cells = %v, want [r1c1 r1c7]", conflict.Cells)
+	}
+
+	var sparse [BoardSize][BoardSize]Value
+	sparse[0][0] = 1
+	_, err = NewBoard(sparse)
+	var tooFew *TooFewGivensError
+	if !errors.As(err, &tooFew) || tooFew.Givens != 1 || tooFew.Minimum != MinimumGivens {
+		t.Fatalf("NewBoard() error = %v, want *TooFewGivensError with 1 given", err)
+	}
+}
+
+func TestEliminatorReturnsEmptyCandidatesError(t *testing.T) {
+	unit := []*Cell{
+		{ID: 0, Row: 0, Col: 0, Marks: CandidateSetOf(1, 2)},
+		{ID: 1, Row: 0, Col: 1, Marks: CandidateSetOf(1, 2)},
+		{ID: 2, Row: 0, Col: 2, Marks: CandidateSetOf(1, 2)},
+	}
+
+	err := EliminateNakedPairs([][]*Cell{unit})
+	var empty *EmptyCandidatesError
+	if !errors.As(err, &empty) {
+		t.Fatalf("EliminateNakedPairs() error = %v, want *EmptyCandidatesError", err)
+	}
+	if empty.Cell != (CellRef{Row: 0, Col: 2}) || empty.Strategy != NakedPairsStrategy {
+		t.Fatalf("empty candidates = %+v, want r1c3 emptied by %s", empty, NakedPairsStrategy)
+	}
+}
+
+func TestSolveUniqueAndParseFileReturnStructuredErrors(t *testing.T) {
+	board, err := NewBoard(mustGridFromString(t, "48392165796734582125187649354813297672956413813679824537268951481425..6969541..82"))
+	if err != nil {
+		t.Fatalf("NewBoard() error = %v", err)
+	}
+	var multiple *MultipleSolutionsError
+	if response := board.SolveUnique(); !errors.As(response.Error, &multiple) || multiple.Count != 2 {
+		t.Fatalf("SolveUnique() error = %v, want *MultipleSolutionsError with 2 solutions", response.Error)
+	}
+
+	path := filepath.Join(t.TempDir(), "boards.txt")
+	content := "" +
+		"003020600900305001001806400008102900700000008006708200002609500800203009005010300\n" +
+		"483921657967345821251876493548132976729564138136798245372689514814253769695417383\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	var rejected []error
+	boards, err := ParseFile(path, OnBoardError(func(err error) {
+		rejected = append(rejected, err)
+	}))
+	if err != nil {
+		t.Fatalf("ParseFile() error = %v", err)
+	}
+	if len(boards) != 1 || len(rejected) != 1 {
+		t.Fatalf("ParseFile() parsed %d boards and rejected %d, want 1 and 1", len(boards), len(rejected))
+	}
+	var diagnostic *Diagnostic
+	var conflict *ConflictError
+	if !errors.As(rejected[0], &diagnostic) || diagnostic.Line != 2 || !errors.As(rejected[0], &conflict) {
+		t.Fatalf("rejected error = %v, want *Diagnostic on line 2 wrapping *ConflictError", rejected[0])
+	}
+}
+
+func TestParseStringReturnsLineDiagnostics(t *testing.T) {
+	input := "" +
+		"003020600900305001001806400008102900700000008006708200002609500800203009005010300\n" +
+		"\n" +
+		"0030206009003050010018064000081029007000000080067082000026095008002030090050103\n" +
+		"003020600900305001001806400008102900700000008006708200002609500800203009005x10300\r\n" +
+		"603020600900305001001806400008102900700000008006708200002609500800203009005010300\n" +
+		"200080300060070084030500209000105408000000000402706000301007040720040060004010003\n"
+
+	boards, diagnostics, err := ParseString(input)
+	if err != nil {
+		t.Fatalf("ParseString() error = %v", err)
+	}
+	if len(boards) != 2 {
+		t.Fatalf("ParseString() parsed %d boards, want 2", len(boards))
+	}
+	want := []struct {
+		line   int
+		column int
+	}{
+		{line: 3, column: 80},
+		{line: 4, column: 76},
+		{line: 5, column: 7},
+	}
+	if len(diagnostics) != len(want) {
+		t.Fatalf("ParseString() returned %d diagnostics, want %d: %v", len(diagnostics), len(want), diagnostics)
+	}
+	for i, expected := range want {
+		if diagnostics[i].Line != expected.line || diagnostics[i].Column != expected.column || diagnostics[i].Reason == "" {
+			t.Fatalf("diagnostics[%d] = %+v, want line %d column %d", i, diagnostics[i], expected.line, expected.column)
+		}
+	}
+	var conflict *ConflictError
+	if !errors.As(diagnostics[2], &conflict) {
+		t.Fatalf("diagnostics[2] = %v, want a wrapped *ConflictError", diagnostics[2])
+	}
+}
+
+func TestParseStrictStopsAtFirstRejectedLine(t *testing.T) {
+	input := "" +
+		"003020600900305001001806400008102900700000008006708200002609500800203009005010300\n" +
+		"not-a-valid-board-line\n" +
+		"200080300060070084030500209000105408000000000402706000301007040720040060004010003\n"
+
+	boards, diagnostics, err := Parse(strings.NewReader(input), Strict())
+	var diagnostic *Diagnostic
+	if !errors.As(err, &diagnostic) || diagnostic.Line != 2 || diagnostic.Column != 1 {
+		t.Fatalf("Parse() error = %v, want a diagnostic on line 2 column 1", err)
+	}
+	if len(boards) != 1 || len(diagnostics) != 1 {
+		t.Fatalf("Parse() returned %d boards and %d diagnostics, want 1 and 1", len(boards), len(diagnostics))
+	}
+}
+
+func TestPuzzleFormatsRoundTripAndDetect(t *testing.T) {
+	const givens = "4.....8.5.3..........7......2.....6.....8.4......1.......6.3.7.5..2.....1.4......"
+	puzzle := &Puzzle{
+		Name:   "top95 #1",
+		Author: "unknown",
+		Source: "top95.txt",
+		Givens: mustGridFromString(t, givens),
+	}
+
+	for _, format := range []Format{LineFormat, GridFormat, SDKFormat, SDMFormat, SSFormat, JSONFormat} {
+		var builder strings.Builder
+		if err := WritePuzzles(&builder, format, []*Puzzle{puzzle, puzzle}); err != nil {
+			t.Fatalf("WritePuzzles(%s) error = %v", format, err)
+		}
+		written := builder.String()
+		if detected := DetectFormat([]byte(written)); detected != format && !(format == SDMFormat && detected == LineFormat) {
+			t.Fatalf("DetectFormat(%s) = %s\n%s", format, detected, written)
+		}
+		puzzles, err := ReadPuzzles(strings.NewReader(written), AutoFormat)
+		if err != nil {
+			t.Fatalf("ReadPuzzles(%s) error = %v\n%s", format, err, written)
+		}
+		if len(puzzles) != 2 || GridString(puzzles[1].Givens) != givens {
+			t.Fatalf("ReadPuzzles(%s) = %d puzzles, want 2 with the original givens\n%s", format, len(puzzles), written)
+		}
+		if (format == SDKFormat || format == JSONFormat) && (puzzles[0].Name != puzzle.Name || puzzles[0].Author != puzzle.Author || puzzles[0].Source != puzzle.Source) {
+			t.Fatalf("ReadPuzzles(%s) metadata = %+v, want %+v", format, puzzles[0], puzzle)
+		}
+	}
+
+	board, err := puzzle.Board()
+	if err != nil {
+		t.Fatalf("Board() error = %v", err)
+	}
+	puzzles, err := ReadPuzzles(strings.NewReader(board.getState()), GridFormat)
+	if err != nil {
+		t.Fatalf("ReadPuzzles(getState) error = %v", err)
+	}
+	if len(puzzles) != 1 || GridString(puzzles[0].Givens) != givens {
+		t.Fatalf("ReadPuzzles(getState) = %d puzzles, want the original givens", len(puzzles))
+	}
+
+	_, err = ReadPuzzles(strings.NewReader("4 . . | . x . | 8 . 5\n"), GridFormat)
+	var diagnostic *Diagnostic
+	if !errors.As(err, &diagnostic) || diagnostic.Line != 1 || diagnostic.Column != 11 {
+		t.Fatalf("ReadPuzzles() error = %v, want a diagnostic on line 1 column 11", err)
+	}
+}
+
+func TestResponseWritersEncodeRecords(t *testing.T) {
+	const easy = "003020600900305001001806400008102900700000008006708200002609500800203009005010300"
+	solvable, err := NewBoard(mustGridFromString(t, easy))
+	if err != nil {
+		t.Fatalf("NewBoard() error = %v", err)
+	}
+	ambiguous, err := NewBoard(mustGridFromString(t, "48392165796734582125187649354813297672956413813679824537268951481425..6969541..82"))
+	if err != nil {
+		t.Fatalf("NewBoard() error = %v", err)
+	}
+	responses := []*SolveResponse{solvable.Solve(), ambiguous.SolveUnique()}
+
+	var ndjson strings.Builder
+	writer := NewNDJSONWriter(&ndjson)
+	for _, response := range responses {
+		if err := writer.Write(response); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	lines := strings.Split(strings.TrimSpace(ndjson.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("NDJSON wrote %d lines, want 2", len(lines))
+	}
+	var solved, rejected SolveRecord
+	if err := json.Unmarshal([]byte(lines[0]), &solved); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &rejected); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if solved.Initial != strings.ReplaceAll(easy, "0", ".") || len(solved.Solution) != 81 || strings.Contains(solved.Solution, ".") {
+		t.Fatalf("solved record grids = %q, %q", solved.Initial, solved.Solution)
+	}
+	if !solved.Solved || solved.DurationNs <= 0 || solved.Strategies == nil || solved.Error != nil {
+		t.Fatalf("solved record = %+v", solved)
+	}
+	if rejected.Solved || rejected.Error == nil || rejected.Error.Type != MultipleSolutionsErrorType || rejected.Error.Count != 2 {
+		t.Fatalf("rejected record error = %+v, want multiple_solutions with count 2", rejected.Error)
+	}
+	if len(solved.Trace) != len(responses[0].SolveTrace) || solved.Trace[0].Strategy == "" || len(solved.Trace[0].Placements) == 0 {
+		t.Fatalf("solved record trace = %+v, want the %d deductions of the solve", solved.Trace, len(responses[0].SolveTrace))
+	}
+
+	stalled, err := NewBoard(mustGridFromString(t, "6.2.5.........3.4..........43...8....1....2........7..5..27...........81...6....."))
+	if err != nil {
+		t.Fatalf("NewBoard() error = %v", err)
+	}
+	data, err := json.Marshal(stalled.SolveLogical())
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var stall SolveRecord
+	if err := json.Unmarshal(data, &stall); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if stall.Stall == nil || stall.Stall.Candidates == "" || len(stall.Stall.OpenCells) == 0 || len(stall.Stall.StrategiesTried) == 0 {
+		t.Fatalf("stalled record stall = %+v, want the stalled candidates, open cells and strategies", stall.Stall)
+	}
+
+	var array strings.Builder
+	jsonWriter := NewJSONWriter(&array)
+	for _, response := range responses {
+		if err := jsonWriter.Write(response); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if err := jsonWriter.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	var records []SolveRecord
+	if err := json.Unmarshal([]byte(array.String()), &records); err != nil || len(records) != 2 {
+		t.Fatalf("JSON array = %d records, error = %v\n%s", len(records), err, array.String())
+	}
+
+	var table strings.Builder
+	csvWriter := NewCSVWriter(&table)
+	for _, response := range responses {
+		if err := csvWriter.Write(response); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if err := csvWriter.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	rows, err := csv.NewReader(strings.NewReader(table.String())).ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if len(rows) != 3 || rows[0][0] != "initial" || rows[2][11] != string(MultipleSolutionsErrorType) {
+		t.Fatalf("CSV rows = %v", rows)
+	}
+}
+
+func TestNewErrorInfoDescribesConflicts(t *testing.T) {
+	info := NewErrorInfo(&Diagnostic{Line: 3, Column: 7, Err: &ConflictError{Unit: BoxUnit, Index: 4, Value: 5, Cells: []CellRef{{Row: 3, Col: 3}, {Row: 5, Col: 5}}}})
+	if info.Type != ConflictErrorType || info.Unit != BoxUnit || info.UnitIndex != 5 || info.Value != 5 {
+		t.Fatalf("NewErrorInfo() = %+v, want a conflict of 5 in box 5", info)
+	}
+	if strings.Join(info.Cells, ",") != "r4c4,r6c6" || info.Line != 3 || info.Column != 7 {
+		t.Fatalf("NewErrorInfo() = %+v, want cells r4c4,r6c6 on line 3 column 7", info)
+	}
+	if NewErrorInfo(nil) != nil {
+		t.Fatal("NewErrorInfo(nil) should be nil")
+	}
+}
+
+func TestSolveAllStreamsResultsInInputOrder(t *testing.T) {
+	boards, err := ParseFile("../../data/easy50.txt")
+	if err != nil {
+		t.Fatalf("ParseFile() error = %v", err)
+	}
+	boards = boards[:12]
+
+	var progress []Progress
+	next := 0
+	for result := range SolveAll(context.Background(), boards, BatchOptions{
+		Workers:  4,
+		Ordered:  true,
+		Progress: func(p Progress) { progress = append(progress, p) },
+	}) {
+		if result.Index != next {
+			t.Fatalf("result index = %d, want %d", result.Index, next)
+		}
+		if !result.Response.IsSolved {
+			t.Fatalf("board %d not solved: %v", result.Index, result.Response.Error)
+		}
+		next++
+	}
+	if next != len(boards) {
+		t.Fatalf("SolveAll() streamed %d results, want %d", next, len(boards))
+	}
+	last := progress[len(progress)-1]
+	if len(progress) != len(boards) || last.Completed != len(boards) || last.Solved != len(boards) || last.Total != len(boards) {
+		t.Fatalf("progress calls = %d, last = %+v", len(progress), last)
+	}
+}
+
+func TestSolveAllAppliesPerBoardTimeout(t *testing.T) {
+	boards := make([]*Board, 0, 3)
+	for i := 0; i < cap(boards); i++ {
+		board, err := NewBoard(mustGridFromString(t, "6.....8.3.4.7.................5.4.7.3..2.....1.6.......2.....5.....8.6......1...."))
+		if err != nil {
+			t.Fatalf("NewBoard() error = %v", err)
+		}
+		boards = append(boards, board)
+	}
+
+	seen := make(map[int]bool)
+	for result := range SolveAll(context.Background(), boards, BatchOptions{Workers: 2, Timeout: time.Nanosecond}) {
+		var canceledErr *CanceledError
+		if !errors.As(result.Response.Error, &canceledErr) || !errors.Is(result.Response.Error, context.DeadlineExceeded) {
+			t.Fatalf("board %d error = %v, want a deadline *CanceledError", result.Index, result.Response.Error)
+		}
+		seen[result.Index] = true
+	}
+	if len(seen) != len(boards) {
+		t.Fatalf("SolveAll() streamed %d distinct results, want %d", len(seen), len(boards))
+	}
+}
+
 func mustGridFromString(t *testing.T, input string) [BoardSize][BoardSize]Value {
 	t.Helper()
 
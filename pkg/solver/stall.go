@@ -0,0 +1,33 @@
+package solver
+
+// Stall is the state of the board where the strategies stopped making progress and backtracking was not allowed.
+// Marks keeps the marks/candidates of every cell, they are empty for the solved cells. Candidates keeps the same
+// state in the pencil-mark layout, so it can be loaded back with ParseCandidates
+type Stall struct {
+	Marks           [BoardSize][BoardSize]CandidateSet
+	Candidates      string
+	OpenCells       []CellRef
+	StrategiesTried []StrategyName
+}
+
+// newStall captures the current marks/candidates and open cells of the board
+func (b *Board) newStall(strategies []Strategy) *Stall {
+	stall := &Stall{
+		Candidates:      b.PencilMarks(),
+		OpenCells:       refsOf(b.unsolvedCells()),
+		StrategiesTried: make([]StrategyName, 0, len(strategies)),
+	}
+	for i := 0; i < BoardSize; i++ {
+		for j := 0; j < BoardSize; j++ {
+			stall.Marks[i][j] = b.data[i][j].Marks
+		}
+	}
+	for _, strategy := range strategies {
+		stall.StrategiesTried = append(stall.StrategiesTried, strategy.Name())
+	}
+	return stall
+}
+
+func (s *Stall) err() error {
+	return &StalledError{OpenCells: len(s.OpenCells), Tried: s.StrategiesTried}
+}
@@ -1,5 +1,7 @@
 package solver
 
+// Strategy is a solving technique eliminating marks/candidates of the board. Apply reports whether the board changed,
+// the changes of strategies which don't record their own deductions are recorded in the solve trace by the solver
 type Strategy interface {
 	Name() StrategyName
 	Apply(*Board) (bool, error)
@@ -30,16 +32,33 @@ var orderedStrategies = []Strategy{
 	strategyFunc{name: LockedCandidatesStrategy, apply: (*Board).eliminateLockedCandidates},
 	strategyFunc{name: XYWingsStrategy, apply: (*Board).eliminateXYWings},
 	strategyFunc{name: XYZWingsStrategy, apply: (*Board).eliminateXYZWings},
+	strategyFunc{name: WWingsStrategy, apply: (*Board).eliminateWWings},
 	strategyFunc{name: XWingsStrategy, apply: (*Board).eliminateXWings},
+	strategyFunc{name: SkyscraperStrategy, apply: (*Board).eliminateSkyscrapers},
+	strategyFunc{name: TwoStringKiteStrategy, apply: (*Board).eliminateTwoStringKites},
+	strategyFunc{name: EmptyRectangleStrategy, apply: (*Board).eliminateEmptyRectangles},
 	strategyFunc{name: SwordFishStrategy, apply: (*Board).eliminateSwordFish},
+	strategyFunc{name: ColoringStrategy, apply: (*Board).eliminateColoring},
+	strategyFunc{name: XYChainsStrategy, apply: (*Board).eliminateXYChains},
+	strategyFunc{name: JellyfishStrategy, apply: (*Board).eliminateJellyfish},
+	strategyFunc{name: FinnedXWingsStrategy, apply: (*Board).eliminateFinnedXWings},
+	strategyFunc{name: FinnedSwordFishStrategy, apply: (*Board).eliminateFinnedSwordFish},
+	strategyFunc{name: FinnedJellyfishStrategy, apply: (*Board).eliminateFinnedJellyfish},
 	strategyFunc{name: HiddenQuadsStrategy, apply: (*Board).eliminateHQ},
 	strategyFunc{name: HiddenTripletsStrategy, apply: (*Board).eliminateHT},
 	strategyFunc{name: HiddenPairsStrategy, apply: (*Board).eliminateHP},
 }
 
-func (b *Board) applyStrategies() (bool, error) {
-	for _, strategy := range orderedStrategies {
-		changed, err := strategy.Apply(b)
+// xyChainsStrategy returns the XY Chains strategy searching the chains of up to maxLength cells
+func xyChainsStrategy(maxLength int) Strategy {
+	return strategyFunc{name: XYChainsStrategy, apply: func(b *Board) error {
+		return EliminateXYChains(b, maxLength)
+	}}
+}
+
+func (b *Board) applyStrategies(strategies []Strategy) (bool, error) {
+	for _, strategy := range strategies {
+		changed, err := b.applyStrategy(strategy)
 		if err != nil {
 			return false, err
 		}
@@ -50,3 +69,17 @@ func (b *Board) applyStrategies() (bool, error) {
 	}
 	return false, nil
 }
+
+// applyStrategy applies the strategy and records the changes as a single deduction if the strategy didn't record any
+func (b *Board) applyStrategy(strategy Strategy) (bool, error) {
+	recorded := len(b.trace)
+	snapshot := snapshotMarks(b.unsolvedCells())
+	changed, err := strategy.Apply(b)
+	if err != nil {
+		return false, err
+	}
+	if changed && len(b.trace) == recorded {
+		snapshot.record(b.recordDeduction, strategy.Name(), nil, 0)
+	}
+	return changed, nil
+}
@@ -0,0 +1,133 @@
+package solver
+
+import "fmt"
+
+// CellRef is the position of a cell on the board by its row and col ids
+type CellRef struct {
+	Row int
+	Col int
+}
+
+// String returns the cell position in the common rXcY notation, both indexes are one based
+func (c CellRef) String() string {
+	return fmt.Sprintf("r%dc%d", c.Row+1, c.Col+1)
+}
+
+// RefStrings returns the given cell positions in the rXcY notation
+func RefStrings(refs []CellRef) []string {
+	cells := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		cells = append(cells, ref.String())
+	}
+	return cells
+}
+
+// refOf returns the position of the given cell
+func refOf(cell *Cell) CellRef {
+	return CellRef{Row: cell.Row, Col: cell.Col}
+}
+
+// refsOf returns the positions of the given cells
+func refsOf(cells []*Cell) []CellRef {
+	refs := make([]CellRef, 0, len(cells))
+	for _, cell := range cells {
+		refs = append(refs, refOf(cell))
+	}
+	return refs
+}
+
+// Placement is a value placed into a cell by a deduction
+type Placement struct {
+	Cell  CellRef
+	Value Value
+}
+
+// Elimination is the set of marks/candidates removed from a cell by a deduction
+type Elimination struct {
+	Cell  CellRef
+	Marks CandidateSet
+}
+
+// ColorCluster is a cluster of coloring, the cells of a digit chained by conjugate pairs and colored in turn. Either
+// every cell of the first color holds the digit or every cell of the second one does
+type ColorCluster struct {
+	Colors [2][]CellRef
+}
+
+// Deduction is a single step of the solving process. Pattern keeps the cells proving the deduction, Fins keeps the fin
+// cells of a finned fish, Clusters keeps the color clusters of a coloring, Digits keeps the digits involved in the
+// pattern, Placements and Eliminations keep the changes it caused on the board
+type Deduction struct {
+	Strategy     StrategyName
+	Pattern      []CellRef
+	Fins         []CellRef
+	Clusters     []ColorCluster
+	Digits       CandidateSet
+	Placements   []Placement
+	Eliminations []Elimination
+}
+
+// recordFunc receives the deductions while strategies are applied, nil means the deductions are not recorded
+type recordFunc func(Deduction)
+
+// marksSnapshot keeps the marks of the cells which might be changed by a deduction
+type marksSnapshot struct {
+	cells []*Cell
+	marks []CandidateSet
+}
+
+// snapshotMarks stores the current marks of the given cells
+func snapshotMarks(cells []*Cell) marksSnapshot {
+	marks := make([]CandidateSet, 0, len(cells))
+	for _, cell := range cells {
+		marks = append(marks, cell.Marks)
+	}
+	return marksSnapshot{cells: cells, marks: marks}
+}
+
+// eliminations returns the marks removed from the snapshot cells since the snapshot is taken
+func (s marksSnapshot) eliminations() []Elimination {
+	eliminations := make([]Elimination, 0)
+	for i, cell := range s.cells {
+		if cell.IsSolved() {
+			continue
+		}
+		removed := s.marks[i].AndNot(cell.Marks)
+		if removed.IsEmpty() || containsElimination(eliminations, refOf(cell)) {
+			continue
+		}
+		eliminations = append(eliminations, Elimination{Cell: refOf(cell), Marks: removed})
+	}
+	return eliminations
+}
+
+// record sends a deduction built from the snapshot to the record function if any marks are eliminated
+func (s marksSnapshot) record(record recordFunc, strategy StrategyName, pattern []*Cell, digits CandidateSet) {
+	if record == nil {
+		return
+	}
+	eliminations := s.eliminations()
+	if len(eliminations) == 0 {
+		return
+	}
+	record(Deduction{
+		Strategy:     strategy,
+		Pattern:      refsOf(pattern),
+		Digits:       digits,
+		Eliminations: eliminations,
+	})
+}
+
+func containsElimination(eliminations []Elimination, ref CellRef) bool {
+	for _, elimination := range eliminations {
+		if elimination.Cell == ref {
+			return true
+		}
+	}
+	return false
+}
+
+// recordDeduction appends the given deduction into the solve trace of the board
+func (b *Board) recordDeduction(deduction Deduction) {
+	b.trace = append(b.trace, deduction)
+}
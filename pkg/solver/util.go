@@ -1,16 +1,14 @@
 package solver
 
 import (
-	"bufio"
 	"fmt"
 	"os"
 	"path/filepath"
 )
 
-// ParseFile simply parses sudoku file and returns a sudoku board for each line
-func ParseFile(path string) ([]*Board, error) {
-	boards := make([]*Board, 0)
-
+// ParseFile simply parses sudoku file and returns a sudoku board for each line. The rejected lines are skipped,
+// use OnBoardError to receive their diagnostics or Strict to fail on the first one
+func ParseFile(path string, options ...ParseOption) ([]*Board, error) {
 	cleanPath := filepath.Clean(path)
 	root, err := os.OpenRoot(filepath.Dir(cleanPath))
 	if err != nil {
@@ -32,31 +30,8 @@ func ParseFile(path string) ([]*Board, error) {
 		}
 	}(file)
 
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		buffer := append(append(make([]byte, 0, BoardSize*BoardSize+Offset), scanner.Bytes()...), EOL)
-		if !IsValidBuffer(buffer) {
-			continue
-		}
-		var boardData [BoardSize][BoardSize]Value
-		index := 0
-		for i := 0; i < BoardSize; i++ {
-			for j := 0; j < BoardSize; j++ {
-				value := buffer[index]
-				if v, ok := HexMap[value]; ok {
-					boardData[i][j] = v
-				}
-				index++
-			}
-		}
-		board, newBoardErr := NewBoard(boardData)
-		if newBoardErr != nil {
-			fmt.Printf("Board error: %s\n", newBoardErr.Error())
-			continue
-		}
-		boards = append(boards, board)
-	}
-	if err := scanner.Err(); err != nil {
+	boards, _, err := Parse(file, options...)
+	if err != nil {
 		return nil, err
 	}
 	return boards, nil
@@ -64,7 +39,7 @@ func ParseFile(path string) ([]*Board, error) {
 
 // UnSolvedCells returns the unsolved cells within the unit
 func UnSolvedCells(cells []*Cell) []*Cell {
-	unsolved := make([]*Cell, 0)
+	unsolved := make([]*Cell, 0, len(cells))
 	for _, cell := range cells {
 		if !cell.IsSolved() {
 			unsolved = append(unsolved, cell)
@@ -1,54 +1,49 @@
 package solver
 
-import "errors"
-
 func hasConflictingValues(data [BoardSize][BoardSize]*Cell) bool {
-	for i := 0; i < BoardSize; i++ {
-		if unitHasConflictingValues(Row(data, i)) || unitHasConflictingValues(Col(data, i)) {
-			return true
-		}
-	}
-	for row := 0; row < BoardSize; row += BlockSize {
-		for col := 0; col < BoardSize; col += BlockSize {
-			if unitHasConflictingValues(Box(data, row, col)) {
-				return true
-			}
-		}
-	}
-	return false
+	return findConflict(data) != nil
 }
 
-func unitHasConflictingValues(unit []*Cell) bool {
-	seen := make(map[Value]struct{}, BoardSize)
-	for _, cell := range unit {
-		if !cell.IsSolved() {
-			continue
-		}
-		if _, ok := seen[cell.Value]; ok {
-			return true
-		}
-		seen[cell.Value] = struct{}{}
-	}
-	return false
+// findConflict returns a *ConflictError for the first unit containing the same value more than once
+func findConflict(data [BoardSize][BoardSize]*Cell) *ConflictError {
+	grid := gridOfData(data)
+	return grid.Conflict()
 }
 
 func (b *Board) validateForSolve() error {
-	if !b.isValid() {
-		return errors.New("invalid board; conflicting values found")
+	grid := b.Grid()
+	if conflict := grid.Conflict(); conflict != nil {
+		return conflict
 	}
 	return nil
 }
 
 func (b *Board) isValid() bool {
-	return !hasConflictingValues(b.data)
+	grid := b.Grid()
+	return grid.Conflict() == nil
 }
 
 func (b *Board) solveError() error {
-	if b.hasInvalidMarks() {
-		return errors.New("invalid board; some cells have invalid marks")
+	for _, cell := range b.unsolvedCells() {
+		if cell.MarksLength() == 0 {
+			return emptyCandidates(cell, "")
+		}
 	}
-	if !b.isValid() {
-		return errors.New("invalid board; conflicting values found")
+	grid := b.Grid()
+	if conflict := grid.Conflict(); conflict != nil {
+		return conflict
+	}
+	return &NoSolutionError{}
+}
+
+// uniquenessError returns an error if the board doesn't have exactly one solution
+func (b *Board) uniquenessError() error {
+	switch count := CountSolutions(b, 2); count {
+	case 0:
+		return &NoSolutionError{}
+	case 1:
+		return nil
+	default:
+		return &MultipleSolutionsError{Count: count}
 	}
-	return errors.New("unable to find a solution")
 }
@@ -1,9 +1,5 @@
 package solver
 
-import (
-	"fmt"
-)
-
 type XYWing struct {
 	Pivot *Cell
 	Wings []*Cell
@@ -25,12 +21,15 @@ func (xy *XYWing) Eliminate(b *Board) error {
 	if !ok {
 		return nil
 	}
-	for _, cell := range xy.WingsIntersect(b) {
+	targets := xy.WingsIntersect(b)
+	snapshot := snapshotMarks(targets)
+	for _, cell := range targets {
 		cell.Marks = cell.Marks.AndNot(marks)
 		if cell.Marks.IsEmpty() {
-			return fmt.Errorf("invalid board: XY: empty marks: cell: %+v", cell)
+			return emptyCandidates(cell, XYWingsStrategy)
 		}
 	}
+	snapshot.record(b.recordDeduction, XYWingsStrategy, xy.Triplet(), ParUnionCells(xy.Triplet()))
 	return nil
 }
 
@@ -43,8 +42,8 @@ func (xy *XYWing) Triplet() []*Cell {
 
 func (xy *XYWing) WingsIntersect(b *Board) []*Cell {
 	intersect := make([]*Cell, 0)
-	aUnit := xy.Wings[0].CellUnits(b)
-	bUnit := xy.Wings[1].CellUnits(b)
+	aUnit := xy.Wings[0].units(b)
+	bUnit := xy.Wings[1].units(b)
 	for _, unit := range aUnit {
 		for _, c := range unit {
 			if IsCellInCollections(c, bUnit) && c.ID != xy.Wings[0].ID && c.ID != xy.Wings[1].ID && xy.Pivot.ID != c.ID {
@@ -91,20 +90,23 @@ func (xyz *XYZWing) Triplet() []*Cell {
 }
 
 func (xyz *XYZWing) Eliminate(b *Board) error {
-	for _, cell := range xyz.XYZIntersect(b) {
+	targets := xyz.XYZIntersect(b)
+	snapshot := snapshotMarks(targets)
+	for _, cell := range targets {
 		cell.Marks = cell.Marks.AndNot(xyz.Intersect())
 		if cell.Marks.IsEmpty() {
-			return fmt.Errorf("invalid board: XYZ: empty marks: cell: %+v", cell)
+			return emptyCandidates(cell, XYZWingsStrategy)
 		}
 	}
+	snapshot.record(b.recordDeduction, XYZWingsStrategy, xyz.Triplet(), ParUnionCells(xyz.Triplet()))
 	return nil
 }
 
 func (xyz *XYZWing) XYZIntersect(b *Board) []*Cell {
 	intersect := make([]*Cell, 0)
-	aUnit := xyz.Wings[0].CellUnits(b)
-	bUnit := xyz.Wings[1].CellUnits(b)
-	cUnit := xyz.Pivot.CellUnits(b)
+	aUnit := xyz.Wings[0].units(b)
+	bUnit := xyz.Wings[1].units(b)
+	cUnit := xyz.Pivot.units(b)
 	for _, unit := range aUnit {
 		for _, c := range unit {
 			if IsCellInCollections(c, bUnit) && IsCellInCollections(c, cUnit) && (c.ID != xyz.Wings[0].ID && c.ID != xyz.Wings[1].ID && c.ID != xyz.Pivot.ID) {
@@ -134,6 +136,75 @@ func EliminateXYZWings(unsolved []*Cell, b *Board) error {
 	return nil
 }
 
+// WWing is a W Wing, two bivalue cells with the same candidates which don't see each other and a conjugate pair of
+// one of these digits, the link, having an end seeing each wing. A link end holds the digit, so one of the wings
+// doesn't and holds the other digit
+type WWing struct {
+	Wings []*Cell
+	Link  []*Cell
+	Mark  CandidateSet
+}
+
+// EliminatedMark returns the digit of the wings which is not the link digit
+func (w *WWing) EliminatedMark() CandidateSet {
+	return w.Wings[0].Marks.AndNot(w.Mark)
+}
+
+// Cells returns the first wing, the link ends and the second wing in chain order
+func (w *WWing) Cells() []*Cell {
+	return []*Cell{w.Wings[0], w.Link[0], w.Link[1], w.Wings[1]}
+}
+
+// Eliminate removes the other digit from the cells seeing both wings
+func (w *WWing) Eliminate(b *Board) error {
+	mark := w.EliminatedMark()
+	targets := commonPeers(b, w.Wings, w.Cells(), mark)
+	return eliminateFromTargets(b.recordDeduction, WWingsStrategy, w.Cells(), targets, mark)
+}
+
+// EliminateWWings eliminates marks/candidates using W Wings
+func EliminateWWings(unsolved []*Cell, b *Board) error {
+	wWings := make([]*WWing, 0)
+	bivalues := make([]*Cell, 0)
+	for _, cell := range unsolved {
+		if cell.Marks.GetCardinality() == 2 {
+			bivalues = append(bivalues, cell)
+		}
+	}
+	for _, pair := range PairCombinations(bivalues) {
+		if pair[0].Marks != pair[1].Marks || pair[0].Sees(pair[1]) {
+			continue
+		}
+		for _, digit := range pair[0].Marks.ToArray() {
+			wWings = append(wWings, wWingLinks(b, pair, CandidateSetOf(digit))...)
+		}
+	}
+	for _, wWing := range wWings {
+		if eliminateErr := wWing.Eliminate(b); eliminateErr != nil {
+			return eliminateErr
+		}
+	}
+	return nil
+}
+
+// wWingLinks returns the W Wings of the wings linked by a conjugate pair of the mark in any unit
+func wWingLinks(b *Board, wings []*Cell, mark CandidateSet) []*WWing {
+	wWings := make([]*WWing, 0)
+	for unit := range b.units {
+		link := candidateCellsForMark(b.units[unit][:], mark)
+		if len(link) != 2 || IsCellInCollection(wings[0], link) || IsCellInCollection(wings[1], link) {
+			continue
+		}
+		for _, ends := range [][2]int{{0, 1}, {1, 0}} {
+			if link[ends[0]].Sees(wings[0]) && link[ends[1]].Sees(wings[1]) {
+				wWings = append(wWings, &WWing{Wings: wings, Link: []*Cell{link[ends[0]], link[ends[1]]}, Mark: mark})
+				break
+			}
+		}
+	}
+	return wWings
+}
+
 func IsTripletXYZWingCandidate(triplet []*Cell, b *Board) (bool, *XYZWing) {
 	var xyzWing XYZWing
 	related := 0
@@ -196,7 +267,7 @@ func IsTripletXYWingCandidate(triplet []*Cell, b *Board) (bool, *XYWing) {
 }
 
 func IsPairRelated(pair []*Cell, board *Board) bool {
-	aUnits := pair[0].CellUnits(board)
+	aUnits := pair[0].units(board)
 	for _, unit := range aUnits {
 		if IsCellInCollection(pair[1], unit) {
 			return true
@@ -236,140 +307,3 @@ func IsTripletUnionHasCardinality(triplet []*Cell, cardinality int) bool {
 	union := ParUnionCells(triplet)
 	return union.GetCardinality() == cardinality
 }
-
-type XWing struct {
-	Up     []*Cell
-	Down   []*Cell
-	Mark   CandidateSet
-	ByRows bool
-}
-
-func line(b *Board, index int, byRows bool) []*Cell {
-	if byRows {
-		return b.row(index)
-	}
-	return b.col(index)
-}
-
-func orthogonalLine(b *Board, index int, byRows bool) []*Cell {
-	if byRows {
-		return b.col(index)
-	}
-	return b.row(index)
-}
-
-func IndexesBitmap(cells []*Cell, byRows bool) CandidateSet {
-	var indexes CandidateSet
-	for _, cell := range cells {
-		if byRows {
-			indexes = indexes.Add(cell.Col + 1)
-		} else {
-			indexes = indexes.Add(cell.Row + 1)
-		}
-	}
-	return indexes
-}
-
-func (x *XWing) TargetUnit(b *Board, wingIndex int) ([]*Cell, error) {
-	if x.ByRows {
-		if x.Up[wingIndex].Col != x.Down[wingIndex].Col {
-			return nil, fmt.Errorf("inconsistent X-Wing col indexes: %+v", x)
-		}
-		return orthogonalLine(b, x.Up[wingIndex].Col, x.ByRows), nil
-	}
-	if x.Up[wingIndex].Row != x.Down[wingIndex].Row {
-		return nil, fmt.Errorf("inconsistent X-Wing row indexes: %+v", x)
-	}
-	return orthogonalLine(b, x.Up[wingIndex].Row, x.ByRows), nil
-}
-
-func (x *XWing) Eliminate(b *Board) error {
-	for wingIndex := range []int{0, 1} {
-		targetUnit, targetUnitErr := x.TargetUnit(b, wingIndex)
-		if targetUnitErr != nil {
-			return targetUnitErr
-		}
-		for _, cell := range targetUnit {
-			if !cell.IsSolved() && cell.ID != x.Up[wingIndex].ID && cell.ID != x.Down[wingIndex].ID {
-				cell.Marks = cell.Marks.AndNot(x.Mark)
-				if cell.Marks.IsEmpty() {
-					return fmt.Errorf("invalid board: X: empty marks: cell: %+v", cell)
-				}
-			}
-		}
-	}
-	return nil
-}
-
-func EliminateXWings(b *Board) error {
-	xWings := make([]*XWing, 0)
-	for _, byRows := range []bool{true, false} {
-		for i := 0; i < BoardSize; i++ {
-			yes, cells, mark := HasXCandidates(line(b, i, byRows))
-			if yes {
-				y, xWing := SearchDownPart(cells, mark, i, b, byRows)
-				if y {
-					xWings = append(xWings, xWing)
-				}
-			}
-		}
-	}
-	for _, xWing := range xWings {
-		if eliminateErr := xWing.Eliminate(b); eliminateErr != nil {
-			return eliminateErr
-		}
-	}
-	return nil
-}
-
-func HasXCandidates(unit []*Cell) (bool, []*Cell, CandidateSet) {
-	union := ParUnionCells(UnSolvedCells(unit))
-	marks := BitmapSingles(union.ToArray())
-	for _, mark := range marks {
-		yes, cells := IsMarkAppearsTwiceInUnit(mark, unit)
-		if yes {
-			return true, cells, mark
-		}
-	}
-	return false, nil, 0
-}
-
-func IsMarkAppearsTwiceInUnit(mark CandidateSet, unit []*Cell) (bool, []*Cell) {
-	cells := make([]*Cell, 0)
-	for _, cell := range unit {
-		if !cell.IsSolved() {
-			intersect := ParIntersect(mark, cell.Marks)
-			if intersect.GetCardinality() == 1 {
-				cells = append(cells, cell)
-			}
-		}
-	}
-	if len(cells) == 2 {
-		// Given mark appears only twice within the row
-		return true, cells
-	}
-	return false, nil
-}
-
-func SearchDownPart(upCells []*Cell, mark CandidateSet, lineIndex int, b *Board, byRows bool) (bool, *XWing) {
-	if lineIndex+1 == BoardSize-1 {
-		return false, nil
-	}
-	for i := lineIndex + 1; i < BoardSize; i++ {
-		yes, downCells := IsMarkAppearsTwiceInUnit(mark, line(b, i, byRows))
-		if yes {
-			upIndexes := IndexesBitmap(upCells, byRows)
-			downIndexes := IndexesBitmap(downCells, byRows)
-			intersect := ParIntersect(upIndexes, downIndexes)
-			if intersect.GetCardinality() == 2 {
-				return true, &XWing{
-					Up:     upCells,
-					Down:   downCells,
-					Mark:   mark,
-					ByRows: byRows,
-				}
-			}
-		}
-	}
-	return false, nil
-}